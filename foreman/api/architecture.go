@@ -211,7 +211,7 @@ func (c *Client) QueryArchitecture(a *ForemanArchitecture) (QueryResponse, error
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,341 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	AuthSourceLdapEndpointPrefix = "auth_source_ldaps"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanAuthSourceLdap API model represents an LDAP authentication
+// source.  Users authenticating against the LDAP server are looked up below the
+// base DN and their attributes are mapped to the user's attributes.  With
+// usergroup sync enabled, Foreman keeps the members of external usergroups in
+// sync with the LDAP groups below the groups base.
+type ForemanAuthSourceLdap struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Hostname and port of the LDAP server
+	Host string
+	Port int
+	// Whether or not to use LDAPS to connect to the LDAP server
+	Tls bool
+	// Type of the LDAP server, one of "free_ipa", "active_directory" or
+	// "posix"
+	ServerType string
+	// Account and password used to bind to the LDAP server.  Anonymous binds
+	// are used if no account is set.  The password is only sent to Foreman,
+	// it is never returned by the API.
+	Account         string
+	AccountPassword string
+	// Base DN of the users and the LDAP filter applied to the user lookup
+	BaseDn     string
+	LdapFilter string
+	// Names of the LDAP attributes mapped to the user's attributes
+	AttrLogin     string
+	AttrFirstname string
+	AttrLastname  string
+	AttrMail      string
+	AttrPhoto     string
+	// Whether or not users are created in Foreman on their first login
+	OntheflyRegister bool
+	// Base DN of the groups and whether or not the members of external
+	// usergroups are kept in sync with the LDAP groups
+	GroupsBase    string
+	UsergroupSync bool
+	// IDs of the locations and organizations the authentication source is
+	// assigned to
+	LocationIds     []int
+	OrganizationIds []int
+}
+
+// ForemanAuthSourceLdap struct used for JSON decode.  Foreman API returns the
+// taxonomies as ForemanObjects.  However, we are only interested in the IDs
+// returned.
+type foremanAuthSourceLdapJSON struct {
+	Host             string          `json:"host"`
+	Port             int             `json:"port"`
+	Tls              bool            `json:"tls"`
+	ServerType       string          `json:"server_type"`
+	Account          string          `json:"account"`
+	BaseDn           string          `json:"base_dn"`
+	LdapFilter       string          `json:"ldap_filter"`
+	AttrLogin        string          `json:"attr_login"`
+	AttrFirstname    string          `json:"attr_firstname"`
+	AttrLastname     string          `json:"attr_lastname"`
+	AttrMail         string          `json:"attr_mail"`
+	AttrPhoto        string          `json:"attr_photo"`
+	OntheflyRegister bool            `json:"onthefly_register"`
+	GroupsBase       string          `json:"groups_base"`
+	UsergroupSync    bool            `json:"usergroup_sync"`
+	Locations        []ForemanObject `json:"locations"`
+	Organizations    []ForemanObject `json:"organizations"`
+}
+
+// Implement the Marshaler interface
+func (fa ForemanAuthSourceLdap) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#MarshalJSON")
+
+	faMap := map[string]interface{}{}
+
+	faMap["name"] = fa.Name
+	faMap["host"] = fa.Host
+	faMap["port"] = fa.Port
+	faMap["tls"] = fa.Tls
+	faMap["server_type"] = fa.ServerType
+	faMap["account"] = fa.Account
+	faMap["base_dn"] = fa.BaseDn
+	faMap["ldap_filter"] = fa.LdapFilter
+	faMap["attr_login"] = fa.AttrLogin
+	faMap["attr_firstname"] = fa.AttrFirstname
+	faMap["attr_lastname"] = fa.AttrLastname
+	faMap["attr_mail"] = fa.AttrMail
+	faMap["attr_photo"] = fa.AttrPhoto
+	faMap["onthefly_register"] = fa.OntheflyRegister
+	faMap["groups_base"] = fa.GroupsBase
+	faMap["usergroup_sync"] = fa.UsergroupSync
+	faMap["location_ids"] = fa.LocationIds
+	faMap["organization_ids"] = fa.OrganizationIds
+
+	// NOTE(ALL): only send the account password if it is set, an empty
+	//   password would otherwise reset the stored password
+	if fa.AccountPassword != "" {
+		faMap["account_password"] = fa.AccountPassword
+	}
+
+	return json.Marshal(faMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct and
+// then convert over to a ForemanAuthSourceLdap struct.
+func (fa *ForemanAuthSourceLdap) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/auth_source_ldap.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fa.ForemanObject = obj
+
+	// decode special JSON struct for keys that changed names
+	var faJSON foremanAuthSourceLdapJSON
+	jsonDecErr = json.Unmarshal(b, &faJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fa.Host = faJSON.Host
+	fa.Port = faJSON.Port
+	fa.Tls = faJSON.Tls
+	fa.ServerType = faJSON.ServerType
+	fa.Account = faJSON.Account
+	fa.BaseDn = faJSON.BaseDn
+	fa.LdapFilter = faJSON.LdapFilter
+	fa.AttrLogin = faJSON.AttrLogin
+	fa.AttrFirstname = faJSON.AttrFirstname
+	fa.AttrLastname = faJSON.AttrLastname
+	fa.AttrMail = faJSON.AttrMail
+	fa.AttrPhoto = faJSON.AttrPhoto
+	fa.OntheflyRegister = faJSON.OntheflyRegister
+	fa.GroupsBase = faJSON.GroupsBase
+	fa.UsergroupSync = faJSON.UsergroupSync
+	fa.LocationIds = foremanObjectArrayToIdIntArray(faJSON.Locations)
+	fa.OrganizationIds = foremanObjectArrayToIdIntArray(faJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateAuthSourceLdap creates a new ForemanAuthSourceLdap with the attributes
+// of the supplied ForemanAuthSourceLdap reference and returns the created
+// ForemanAuthSourceLdap reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateAuthSourceLdap(a *ForemanAuthSourceLdap) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLdapEndpointPrefix)
+
+	authSourceLdapJSONBytes, jsonEncErr := WrapJson("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request data contains the account password, do not log it
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(authSourceLdapJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &createdAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdAuthSourceLdap: [%+v]", createdAuthSourceLdap)
+
+	return &createdAuthSourceLdap, nil
+}
+
+// ReadAuthSourceLdap reads the attributes of a ForemanAuthSourceLdap identified
+// by the supplied ID and returns a ForemanAuthSourceLdap reference.
+func (c *Client) ReadAuthSourceLdap(id int) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &readAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readAuthSourceLdap: [%+v]", readAuthSourceLdap)
+
+	return &readAuthSourceLdap, nil
+}
+
+// UpdateAuthSourceLdap updates a ForemanAuthSourceLdap's attributes.  The
+// authentication source with the ID of the supplied ForemanAuthSourceLdap will
+// be updated. A new ForemanAuthSourceLdap reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateAuthSourceLdap(a *ForemanAuthSourceLdap) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, a.Id)
+
+	authSourceLdapJSONBytes, jsonEncErr := WrapJson("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request data contains the account password, do not log it
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(authSourceLdapJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &updatedAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedAuthSourceLdap: [%+v]", updatedAuthSourceLdap)
+
+	return &updatedAuthSourceLdap, nil
+}
+
+// DeleteAuthSourceLdap deletes the ForemanAuthSourceLdap identified by the
+// supplied ID
+func (c *Client) DeleteAuthSourceLdap(id int) error {
+	log.Tracef("foreman/api/auth_source_ldap.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryAuthSourceLdap queries for a ForemanAuthSourceLdap based on the
+// attributes of the supplied ForemanAuthSourceLdap reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// authentication sources.
+func (c *Client) QueryAuthSourceLdap(a *ForemanAuthSourceLdap) (QueryResponse, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLdapEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + a.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanAuthSourceLdap for the results
+	results := []ForemanAuthSourceLdap{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanAuthSourceLdap to []interface and set
+	// the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -2,12 +2,15 @@ package api
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 
@@ -24,6 +27,14 @@ const (
 	// the time of writing) are 1 and 2, which version 1 planning on being
 	// deprecated after version 1.17.
 	FOREMAN_API_VERSION = "2"
+	// Number of results requested per page when the client follows the
+	// pages of a query response.  Used if the client configuration does not
+	// supply a page size.
+	FOREMAN_QUERY_PER_PAGE = 100
+	// Minimum and maximum time to wait between retries of a failed request
+	// if the client configuration does not supply the wait times.
+	FOREMAN_RETRY_WAIT_MIN = 1 * time.Second
+	FOREMAN_RETRY_WAIT_MAX = 30 * time.Second
 )
 
 // ----------------------------------------------------------------------------
@@ -31,10 +42,25 @@ const (
 // ----------------------------------------------------------------------------
 
 // Credentials used to authenticate the client against the remote server - in
-// this case, the Foreman API
+// this case, the Foreman API.
+//
+// The client authenticates with OAuth if a consumer key is set.  Otherwise it
+// uses HTTP basic authentication with the username and either the personal
+// access token or, if no token is set, the password.
 type ClientCredentials struct {
 	Username string
 	Password string
+	// Personal access token of the user.  Used in place of the password.
+	PersonalAccessToken string
+	// OAuth consumer key and secret configured in Foreman's settings.
+	// Requests are signed with two-legged OAuth 1.0a.
+	OAuthConsumerKey    string
+	OAuthConsumerSecret string
+}
+
+// usesOAuth returns whether the credentials are OAuth consumer credentials
+func (c ClientCredentials) usesOAuth() bool {
+	return c.OAuthConsumerKey != ""
 }
 
 // Configurable features to apply the REST client
@@ -45,6 +71,31 @@ type ClientConfig struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information
 	TLSInsecureEnabled bool
+	// Certificate authorities used to verify the server's certificate.  If
+	// nil, the host's root CA set is used.
+	TLSRootCAs *x509.CertPool
+	// Client certificates presented to the server for mutual TLS
+	// authentication
+	TLSClientCertificates []tls.Certificate
+	// Name used to verify the server's certificate instead of the hostname
+	// of the server's URL
+	TLSServerName string
+	// Number of results to request per page when querying the API.  The
+	// client follows the pages of a query response until all results have
+	// been retrieved.  Values less than 1 fall back to
+	// FOREMAN_QUERY_PER_PAGE.
+	QueryPerPage int
+	// Number of times a request is retried when the server could not be
+	// reached or responded with a status code indicating a temporary failure
+	// (429, 502, 503, 504).  A value of 0 disables retries.
+	MaxRetries int
+	// Minimum and maximum time to wait between retries.  The wait time grows
+	// exponentially from the minimum and is capped at the maximum.  A
+	// Retry-After header sent by the server takes precedence.  Values less
+	// than or equal to 0 fall back to FOREMAN_RETRY_WAIT_MIN and
+	// FOREMAN_RETRY_WAIT_MAX.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
 
 type Client struct {
@@ -52,6 +103,12 @@ type Client struct {
 	server Server
 	// Set of credentials to authenticate the client
 	credentials ClientCredentials
+	// Number of results to request per page when querying the API
+	queryPerPage int
+	// Retry policy for failed requests
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
 	// Instance of the HTTP client used to communicate with the webservice.  After
 	// the intial setup, the client should never modify or interact directly with
 	// the underlying HTTP client and should instead use the helper functions.
@@ -60,8 +117,64 @@ type Client struct {
 
 // KVParameters are used in all inline Parameter Maps. i.e. Host, HostGroup
 type ForemanKVParameter struct {
+	// ID of an existing parameter.  Parameters without an ID are created.
+	Id    int    `json:"id,omitempty"`
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// Type Foreman parses the value as, ie: "boolean" or "yaml".  When empty,
+	// the type of an existing parameter is left unchanged.
+	ParameterType string `json:"parameter_type,omitempty"`
+	// Whether Foreman hides the value of the parameter in its UI and API
+	HiddenValue bool `json:"hidden_value"`
+
+	// NOTE(ALL): See the note in ForemanInterfacesAttribute's Destroy
+	//   property.  Parameters are removed the same way.
+	Destroy bool `json:"_destroy,omitempty"`
+}
+
+// foremanKVParameterJSON struct used for JSON decode.  Foreman returns the
+// value of a parameter with a type other than string as that JSON type.
+//
+// NOTE(ALL): Foreman renders the hidden flag of a parameter as "hidden_value?"
+//   while it expects "hidden_value" in requests.  Both keys are accepted.
+type foremanKVParameterJSON struct {
+	Id            int         `json:"id"`
+	Name          string      `json:"name"`
+	Value         interface{} `json:"value"`
+	ParameterType string      `json:"parameter_type"`
+	HiddenValue   bool        `json:"hidden_value"`
+	HiddenValueQ  bool        `json:"hidden_value?"`
+}
+
+// Custom JSON unmarshal function.  Values which are not a JSON string are
+// converted to their JSON representation, ie: true => "true".
+func (p *ForemanKVParameter) UnmarshalJSON(b []byte) error {
+	var pJSON foremanKVParameterJSON
+	jsonDecErr := json.Unmarshal(b, &pJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	p.Id = pJSON.Id
+	p.Name = pJSON.Name
+	p.ParameterType = pJSON.ParameterType
+	p.HiddenValue = pJSON.HiddenValue || pJSON.HiddenValueQ
+	p.Destroy = false
+
+	switch value := pJSON.Value.(type) {
+	case nil:
+		p.Value = ""
+	case string:
+		p.Value = value
+	default:
+		valueBytes, jsonEncErr := json.Marshal(value)
+		if jsonEncErr != nil {
+			return jsonEncErr
+		}
+		p.Value = string(valueBytes)
+	}
+
+	return nil
 }
 
 // NewClient creates a new instance of the REST client for communication with
@@ -75,21 +188,43 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		cfg,
 	)
 
-	// Initialize the HTTP client for use by the provider.  The insecure flag
-	// from the provider config is used when configuring the TLS settings of
-	// the HTTP client.
+	// Initialize the HTTP client for use by the provider.  The insecure flag,
+	// CA certificates, client certificates and server name from the provider
+	// config are used when configuring the TLS settings of the HTTP client.
 	cleanClient := cleanhttp.DefaultClient()
 	transCfg := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: cfg.TLSInsecureEnabled,
+			RootCAs:            cfg.TLSRootCAs,
+			Certificates:       cfg.TLSClientCertificates,
+			ServerName:         cfg.TLSServerName,
 		},
 	}
 	cleanClient.Transport = transCfg
+	queryPerPage := cfg.QueryPerPage
+	if queryPerPage < 1 {
+		queryPerPage = FOREMAN_QUERY_PER_PAGE
+	}
+	retryWaitMin := cfg.RetryWaitMin
+	if retryWaitMin <= 0 {
+		retryWaitMin = FOREMAN_RETRY_WAIT_MIN
+	}
+	retryWaitMax := cfg.RetryWaitMax
+	if retryWaitMax <= 0 {
+		retryWaitMax = FOREMAN_RETRY_WAIT_MAX
+	}
+	if retryWaitMax < retryWaitMin {
+		retryWaitMax = retryWaitMin
+	}
 	// Initialize and return the unauthenticated client.
 	client := Client{
-		httpClient:  cleanClient,
-		server:      s,
-		credentials: c,
+		httpClient:   cleanClient,
+		server:       s,
+		credentials:  c,
+		queryPerPage: queryPerPage,
+		maxRetries:   cfg.MaxRetries,
+		retryWaitMin: retryWaitMin,
+		retryWaitMax: retryWaitMax,
 	}
 	return &client
 }
@@ -110,7 +245,7 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 //   User-Agent
 //   ACCEPT
 //   Content-Type
-//   Authorization
+//   Authorization (unless the client uses OAuth)
 //
 // method
 //   The HTTP Verb to use.  This should correspond to a 'Method*' constant
@@ -166,7 +301,16 @@ func (client *Client) NewRequest(method string, endpoint string, body io.Reader)
 	req.Header.Add("User-Agent", "terraform-provider-foreman")
 	req.Header.Add("Accept", "application/json,version="+FOREMAN_API_VERSION)
 	req.Header.Add("Content-Type", "application/json")
-	req.SetBasicAuth(client.credentials.Username, client.credentials.Password)
+	// NOTE(ALL): OAuth requests are signed right before each attempt to send
+	//   them in Client.Send() - the signature depends on the final URL and
+	//   must not be reused.
+	if !client.credentials.usesOAuth() {
+		password := client.credentials.Password
+		if client.credentials.PersonalAccessToken != "" {
+			password = client.credentials.PersonalAccessToken
+		}
+		req.SetBasicAuth(client.credentials.Username, password)
+	}
 	return req, nil
 }
 
@@ -202,6 +346,11 @@ func isValidRequestMethod(method string) bool {
 // the StatusCode, response. Serves as a facade to the Client's underlying
 // HTTP client.
 //
+// Requests which could not be sent or were answered with a status code
+// indicating a temporary failure (429, 502, 503, 504) are retried according
+// to the client's retry policy.  The request body is replayed for each
+// attempt.
+//
 // If an error is encountered when reading the server's response, the returned
 // StatusCode will be -1.  If an error is encountered during any step of the
 // the send and response parsing, an empty slice will be returned as the
@@ -219,6 +368,64 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 		return -1, emptySlice, fmt.Errorf("Client trying to send a nil request")
 	}
 
+	for attempt := 0; ; attempt++ {
+		// NOTE(ALL): the body of the previous attempt has been consumed by
+		//   the transport - get a fresh copy of it before sending again.
+		//   http.NewRequest() sets GetBody for the in-memory readers used by
+		//   the client.
+		if attempt > 0 && request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return -1, emptySlice, bodyErr
+			}
+			request.Body = body
+		}
+
+		statusCode, respBody, retryAfter, sendErr := client.sendOnce(request)
+
+		if attempt >= client.maxRetries || !shouldRetry(request, statusCode, sendErr) {
+			return statusCode, respBody, sendErr
+		}
+
+		wait := client.retryWait(attempt, retryAfter)
+		log.Infof(
+			"Retrying [%s %s] in [%s] (attempt %d of %d). statusCode: [%d], "+
+				"error: [%v]",
+			request.Method,
+			request.URL,
+			wait,
+			attempt+1,
+			client.maxRetries,
+			statusCode,
+			sendErr,
+		)
+
+		select {
+		case <-request.Context().Done():
+			return statusCode, respBody, request.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// sendOnce sends the request a single time and reads the server's response.
+// Besides the values returned by Send(), the value of the response's
+// Retry-After header is returned.  If the client uses OAuth, the request is
+// signed before it is sent.
+func (client *Client) sendOnce(request *http.Request) (int, []byte, string, error) {
+	emptySlice := []byte{}
+
+	if client.credentials.usesOAuth() {
+		if signErr := client.signOAuthRequest(request); signErr != nil {
+			log.Errorf(
+				"Error encountered when signing HTTP request\n"+
+					"  Error: %s",
+				signErr.Error(),
+			)
+			return -1, emptySlice, "", signErr
+		}
+	}
+
 	// Send the request to the server
 	resp, respErr := client.httpClient.Do(request)
 	if respErr != nil {
@@ -227,13 +434,15 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			respErr.Error(),
 		)
-		return -1, emptySlice, respErr
+		return -1, emptySlice, "", respErr
 	}
 	// NOTE(ALL): Golang stdlib dictates that it is the caller's resposibility
 	//   to close the response body.  See net/http Response type for more
 	//   information.
 	defer resp.Body.Close()
 
+	retryAfter := resp.Header.Get("Retry-After")
+
 	// Read the server's response
 	respBody, readErr := ioutil.ReadAll(resp.Body)
 	if readErr != nil {
@@ -242,17 +451,18 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			readErr.Error(),
 		)
-		return resp.StatusCode, emptySlice, readErr
+		return resp.StatusCode, emptySlice, retryAfter, readErr
 	}
 
-	return resp.StatusCode, respBody, nil
+	return resp.StatusCode, respBody, retryAfter, nil
 }
 
 // SendAndParse sends an HTTP request generated by Client.NewRequest() and
 // parses the server's response for errors.  If an error is encountered during
-// the sending or response parsing, the function returns an error.  Otherwise,
-// the server's response is unmarshalled into the supplied interface (if the
-// interface is not nil).
+// the sending or response parsing, the function returns an error.  Responses
+// with a status code outside of the 2xx range are returned as an *APIError.
+// Otherwise, the server's response is unmarshalled into the supplied
+// interface (if the interface is not nil).
 func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	log.Tracef("foreman/api/client.go#SendAndParse")
 
@@ -275,16 +485,7 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	)
 
 	if statusCode < 200 || statusCode > 299 {
-		return fmt.Errorf(
-			"HTTP Error:{\n"+
-				"  endpoint:   [%s]\n"+
-				"  statusCode: [%d]\n"+
-				"  respBody:   [%s]\n"+
-				"}",
-			req.URL,
-			statusCode,
-			respBody,
-		)
+		return newAPIError(req, statusCode, respBody)
 	}
 
 	if obj != nil {
@@ -293,6 +494,57 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	return nil
 }
 
+// SendAndParseQuery sends a query request generated by Client.NewRequest()
+// and collects the results of every page of the server's response.  The
+// "page" and "per_page" parameters are set on the request's query string and
+// pages are requested until the number of collected results reaches the
+// subtotal reported by the server or the server returns an empty page.
+//
+// The supplied QueryResponse carries the metadata of the last page read and
+// the results of all pages.
+func (client *Client) SendAndParseQuery(req *http.Request, queryResponse *QueryResponse) error {
+	log.Tracef("foreman/api/client.go#SendAndParseQuery")
+
+	if req == nil {
+		log.Errorf("Client trying to send a nil request")
+		return fmt.Errorf("Client trying to send a nil request")
+	}
+
+	results := []interface{}{}
+	for page := 1; ; page++ {
+		// NOTE(ALL): query requests are sent without a body - cloning the
+		//   request is enough to send it again for the next page
+		pageReq := req.Clone(req.Context())
+		reqQuery := pageReq.URL.Query()
+		reqQuery.Set("page", strconv.Itoa(page))
+		reqQuery.Set("per_page", strconv.Itoa(client.queryPerPage))
+		pageReq.URL.RawQuery = reqQuery.Encode()
+
+		var pageResponse QueryResponse
+		sendErr := client.SendAndParse(pageReq, &pageResponse)
+		if sendErr != nil {
+			return sendErr
+		}
+
+		log.Debugf(
+			"page: [%d], results: [%d], subtotal: [%d]",
+			page,
+			len(pageResponse.Results),
+			pageResponse.Subtotal,
+		)
+
+		results = append(results, pageResponse.Results...)
+		*queryResponse = pageResponse
+
+		if len(pageResponse.Results) == 0 || len(results) >= pageResponse.Subtotal {
+			break
+		}
+	}
+	queryResponse.Results = results
+
+	return nil
+}
+
 func WrapJson(name string, item interface{}) ([]byte, error) {
 	wrapped := map[string]interface{}{
 		name: item,
@@ -1,12 +1,24 @@
 package api
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 // ----------------------------------------------------------------------------
@@ -149,6 +161,87 @@ func TestNewClient_ConfigTLSInsecureEnabled(t *testing.T) {
 	}
 }
 
+// Ensures the client verifies the server's certificate against the
+// configured CAs and server name and presents its client certificate to the
+// server
+func TestNewClient_ConfigTLSCertificates(t *testing.T) {
+	urlMux := http.NewServeMux()
+	server := httptest.NewUnstartedServer(urlMux)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAnyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - requires a client certificate
+	urlMux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 1 {
+			t.Errorf(
+				"Client did not present its client certificate. Expected [1] "+
+					"certificate, got [%d]",
+				len(r.TLS.PeerCertificates),
+			)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// self-signed client certificate
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "proxy.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, _ := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	clientCert := tls.Certificate{
+		Certificate: [][]byte{certDER},
+		PrivateKey:  key,
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	serverURL, _ := url.Parse(server.URL)
+	serv := Server{
+		URL: *serverURL,
+	}
+	cred := ClientCredentials{}
+
+	testCases := []struct {
+		ServerName    string
+		ExpectSuccess bool
+	}{
+		// NOTE(ALL): the certificate of the test server is issued for
+		//   "example.com" and 127.0.0.1
+		{ServerName: "", ExpectSuccess: true},
+		{ServerName: "example.com", ExpectSuccess: true},
+		{ServerName: "foreman.example.org", ExpectSuccess: false},
+	}
+
+	for _, testCase := range testCases {
+		conf := ClientConfig{
+			TLSRootCAs:            rootCAs,
+			TLSClientCertificates: []tls.Certificate{clientCert},
+			TLSServerName:         testCase.ServerName,
+		}
+		client := NewClient(serv, cred, conf)
+
+		req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+		sendErr := client.SendAndParse(req, nil)
+		if (sendErr == nil) != testCase.ExpectSuccess {
+			t.Errorf(
+				"Client did not verify the server's certificate as expected for "+
+					"server name [%s]. Expected success [%t], got error [%v]",
+				testCase.ServerName,
+				testCase.ExpectSuccess,
+				sendErr,
+			)
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Client.NewRequest
 // ----------------------------------------------------------------------------
@@ -293,6 +386,33 @@ func TestNewRequest_Header(t *testing.T) {
 
 }
 
+// Ensures Client.NewRequest() sends the personal access token in place of
+// the password when one is set
+func TestNewRequest_PersonalAccessToken(t *testing.T) {
+	serv := Server{}
+	cred := ClientCredentials{
+		Username:            "Admin",
+		Password:            "ChangeMe",
+		PersonalAccessToken: "0123456789abcdef",
+	}
+	conf := ClientConfig{}
+	client := NewClient(serv, cred, conf)
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+
+	username, password, ok := req.BasicAuth()
+	if !ok || username != cred.Username || password != cred.PersonalAccessToken {
+		t.Fatalf(
+			"http.Request returned by Client.NewRequest() has incorrect basic auth "+
+				"credentials. Expected [%s:%s], got [%s:%s].\n",
+			cred.Username,
+			cred.PersonalAccessToken,
+			username,
+			password,
+		)
+	}
+}
+
 // Ensures Client.NewRequest() is properly concatenating the server's URL
 // and the endpoint when constructing the request's URL.
 func TestNewRequest_URL(t *testing.T) {
@@ -460,3 +580,151 @@ func TestSendAndParseStatusCodeNoError(t *testing.T) {
 		)
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Client.SendAndParseQuery
+// ----------------------------------------------------------------------------
+
+// Ensure SendAndParseQuery() follows the pages of a query response until all
+// results matching the search have been collected
+func TestSendAndParseQuery_Pagination(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		QueryPerPage: 2,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - serves 5 results, 2 per page
+	subtotal := 5
+	requestedPages := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		requestedPages++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if perPage != 2 {
+			t.Errorf(
+				"Client.SendAndParseQuery() did not request the configured page "+
+					"size. Expected [2], got [%d]",
+				perPage,
+			)
+		}
+		results := []string{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= subtotal; id++ {
+			results = append(results, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		fmt.Fprintf(
+			w,
+			`{"total":10,"subtotal":%d,"page":%d,"per_page":%d,"results":[%s]}`,
+			subtotal,
+			page,
+			perPage,
+			strings.Join(results, ","),
+		)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	queryResponse := QueryResponse{}
+	sendErr := client.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		t.Fatalf(
+			"Client.SendAndParseQuery() returned an error. Expected [nil], "+
+				"got [%s]",
+			sendErr,
+		)
+	}
+
+	if requestedPages != 3 {
+		t.Errorf(
+			"Client.SendAndParseQuery() requested the wrong number of pages. "+
+				"Expected [3], got [%d]",
+			requestedPages,
+		)
+	}
+
+	if len(queryResponse.Results) != subtotal {
+		t.Errorf(
+			"Client.SendAndParseQuery() did not collect all results. "+
+				"Expected [%d], got [%d]",
+			subtotal,
+			len(queryResponse.Results),
+		)
+	}
+}
+
+// Ensure SendAndParseQuery() stops requesting pages when the server responds
+// with an empty page
+func TestSendAndParseQuery_EmptyPage(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - reports results but never returns any
+	requestedPages := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		requestedPages++
+		fmt.Fprint(w, `{"total":3,"subtotal":3,"page":1,"per_page":100,"results":[]}`)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	queryResponse := QueryResponse{}
+	sendErr := client.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		t.Fatalf(
+			"Client.SendAndParseQuery() returned an error. Expected [nil], "+
+				"got [%s]",
+			sendErr,
+		)
+	}
+
+	if requestedPages != 1 {
+		t.Errorf(
+			"Client.SendAndParseQuery() requested the wrong number of pages. "+
+				"Expected [1], got [%d]",
+			requestedPages,
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ForemanKVParameter
+// ----------------------------------------------------------------------------
+
+// Ensure ForemanKVParameter's UnmarshalJSON() reads the ID, type and hidden
+// flag and converts values of other JSON types to a string
+func TestForemanKVParameterUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		json     string
+		expected ForemanKVParameter
+	}{
+		{`{"id":1,"name":"foo","value":"bar"}`, ForemanKVParameter{Id: 1, Name: "foo", Value: "bar"}},
+		{`{"id":2,"name":"enabled","value":true}`, ForemanKVParameter{Id: 2, Name: "enabled", Value: "true"}},
+		{`{"id":3,"name":"count","value":3}`, ForemanKVParameter{Id: 3, Name: "count", Value: "3"}},
+		{`{"id":4,"name":"list","value":["a","b"]}`, ForemanKVParameter{Id: 4, Name: "list", Value: `["a","b"]`}},
+		{`{"id":5,"name":"empty","value":null}`, ForemanKVParameter{Id: 5, Name: "empty", Value: ""}},
+		{`{"id":6,"name":"flag","value":false,"parameter_type":"boolean"}`, ForemanKVParameter{Id: 6, Name: "flag", Value: "false", ParameterType: "boolean"}},
+		{`{"id":7,"name":"secret","value":"*****","hidden_value?":true}`, ForemanKVParameter{Id: 7, Name: "secret", Value: "*****", HiddenValue: true}},
+		{`{"id":8,"name":"secret","value":"*****","hidden_value":true}`, ForemanKVParameter{Id: 8, Name: "secret", Value: "*****", HiddenValue: true}},
+	}
+
+	for _, testCase := range testCases {
+		var param ForemanKVParameter
+		if jsonDecErr := json.Unmarshal([]byte(testCase.json), &param); jsonDecErr != nil {
+			t.Fatalf(
+				"ForemanKVParameter UnmarshalJSON returned an error for [%s]: [%s]",
+				testCase.json,
+				jsonDecErr,
+			)
+		}
+		if !reflect.DeepEqual(param, testCase.expected) {
+			t.Errorf(
+				"ForemanKVParameter UnmarshalJSON did not decode [%s]. "+
+					"Expected [%+v], got [%+v]",
+				testCase.json,
+				testCase.expected,
+				param,
+			)
+		}
+	}
+}
@@ -25,8 +25,36 @@ type ForemanCommonParameter struct {
 	ForemanObject
 
 	// The CommonParameter we actually send
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name          string `json:"name"`
+	Value         string `json:"value"`
+	ParameterType string `json:"parameter_type,omitempty"`
+	HiddenValue   bool   `json:"hidden_value"`
+}
+
+// Custom JSON unmarshal function.  The name, value and type of the common
+// parameter are decoded the same way as an inline ForemanKVParameter.
+func (fcp *ForemanCommonParameter) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fcp.ForemanObject = fo
+
+	var kv ForemanKVParameter
+	jsonDecErr = json.Unmarshal(b, &kv)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fcp.Name = kv.Name
+	fcp.Value = kv.Value
+	fcp.ParameterType = kv.ParameterType
+	fcp.HiddenValue = kv.HiddenValue
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -69,6 +97,8 @@ func (c *Client) CreateCommonParameter(d *ForemanCommonParameter) (*ForemanCommo
 	d.Id = createdCommonParameter.Id
 	d.Name = createdCommonParameter.Name
 	d.Value = createdCommonParameter.Value
+	d.ParameterType = createdCommonParameter.ParameterType
+	d.HiddenValue = createdCommonParameter.HiddenValue
 	return d, nil
 }
 
@@ -99,6 +129,8 @@ func (c *Client) ReadCommonParameter(d *ForemanCommonParameter, id int) (*Forema
 	d.Id = readCommonParameter.Id
 	d.Name = readCommonParameter.Name
 	d.Value = readCommonParameter.Value
+	d.ParameterType = readCommonParameter.ParameterType
+	d.HiddenValue = readCommonParameter.HiddenValue
 	return d, nil
 }
 
@@ -136,6 +168,8 @@ func (c *Client) UpdateCommonParameter(d *ForemanCommonParameter, id int) (*Fore
 	d.Id = updatedCommonParameter.Id
 	d.Name = updatedCommonParameter.Name
 	d.Value = updatedCommonParameter.Value
+	d.ParameterType = updatedCommonParameter.ParameterType
+	d.HiddenValue = updatedCommonParameter.HiddenValue
 	return d, nil
 }
 
@@ -185,7 +219,7 @@ func (c *Client) QueryCommonParameter(d *ForemanCommonParameter) (QueryResponse,
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
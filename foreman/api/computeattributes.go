@@ -252,7 +252,7 @@ func (c *Client) QueryComputeAttributes(t *ForemanComputeAttributes) (QueryRespo
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
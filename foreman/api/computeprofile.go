@@ -163,7 +163,7 @@ func (c *Client) QueryComputeProfile(t *ForemanComputeProfile) (QueryResponse, e
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -248,7 +248,7 @@ func (c *Client) QueryComputeResource(d *ForemanComputeResource) (QueryResponse,
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
 
 // ----------------------------------------------------------------------------
@@ -42,6 +47,18 @@ func intIdToJSONString(id int) interface{} {
 	return strconv.Itoa(id)
 }
 
+// setOrganizationIds adds the "organization_ids" of an object which belongs to
+// the organization with the given ID to the object's JSON map.
+//
+// Objects are associated with taxonomies through a list of IDs.  The list is
+// only added if an organization was supplied, otherwise Foreman would drop
+// the object's existing associations.
+func setOrganizationIds(objMap map[string]interface{}, organizationId int) {
+	if organizationId > 0 {
+		objMap["organization_ids"] = []interface{}{intIdToJSONString(organizationId)}
+	}
+}
+
 // foremanObjectArrayToIdIntArray converts an array of ForemanObject structs
 // into an integer array containing the ForemanObject's IDs.
 //
@@ -111,3 +128,59 @@ type QueryResponseSort struct {
 	// Which field to order by
 	By string `json:"by,omitempty"`
 }
+
+// ----------------------------------------------------------------------------
+// Foreman API Search
+// ----------------------------------------------------------------------------
+
+// search queries the endpoint with a Foreman scoped search expression and an
+// optional order expression (ie: "name DESC") and returns a QueryResponse
+// struct containing the query/response metadata.  The results of all pages
+// are decoded into the supplied results reference, which should be a pointer
+// to a slice of the endpoint's API model.  The Results attribute of the
+// returned QueryResponse is left for the caller to set.
+func (c *Client) search(endpoint string, search string, order string, results interface{}) (QueryResponse, error) {
+	log.Tracef("foreman/api/core.go#search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", endpoint)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	if search != "" {
+		reqQuery.Set("search", search)
+	}
+	if order != "" {
+		reqQuery.Set("order", order)
+	}
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into the supplied results
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+
+	return queryResponse, nil
+}
@@ -174,7 +174,7 @@ func (c *Client) QueryDefaultTemplate(d *ForemanDefaultTemplate) (QueryResponse,
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
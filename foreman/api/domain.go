@@ -26,9 +26,79 @@ type ForemanDomain struct {
 
 	// Fully qualified domain name
 	Fullname string `json:"fullname"`
+	// ID of the smart proxy providing DNS for this domain
+	DnsId int `json:"dns_id"`
 
 	// Map of DomainParameters
 	DomainParameters []ForemanKVParameter `json:"domain_parameters_attributes,omitempty"`
+	// ID of the organization the domain belongs to
+	OrganizationId int `json:"organization_id"`
+}
+
+// ForemanDomain struct used for JSON decode.  Foreman API returns the
+// organizations of a domain as a list of ForemanObjects.  Older Foreman
+// versions only return the DNS proxy as a nested object.
+type foremanDomainJSON struct {
+	Fullname      string          `json:"fullname"`
+	DnsId         int             `json:"dns_id"`
+	Dns           *ForemanObject  `json:"dns"`
+	Organizations []ForemanObject `json:"organizations"`
+
+	Parameters []ForemanKVParameter `json:"parameters"`
+}
+
+// Implement the Marshaler interface
+func (fd ForemanDomain) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/domain.go#MarshalJSON")
+
+	fdMap := map[string]interface{}{}
+
+	fdMap["name"] = fd.Name
+	fdMap["fullname"] = fd.Fullname
+	fdMap["dns_id"] = intIdToJSONString(fd.DnsId)
+
+	setOrganizationIds(fdMap, fd.OrganizationId)
+	if len(fd.DomainParameters) > 0 {
+		fdMap["domain_parameters_attributes"] = fd.DomainParameters
+	}
+
+	log.Debugf("fdMap: [%v]", fdMap)
+
+	return json.Marshal(fdMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanDomain struct.
+func (fd *ForemanDomain) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/domain.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fd.ForemanObject = fo
+
+	// decode special JSON struct for keys that changed names
+	var fdJSON foremanDomainJSON
+	jsonDecErr = json.Unmarshal(b, &fdJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fd.Fullname = fdJSON.Fullname
+	fd.DnsId = fdJSON.DnsId
+	if fd.DnsId == 0 && fdJSON.Dns != nil {
+		fd.DnsId = fdJSON.Dns.Id
+	}
+	if orgIds := foremanObjectArrayToIdIntArray(fdJSON.Organizations); len(orgIds) > 0 {
+		fd.OrganizationId = orgIds[0]
+	}
+	fd.DomainParameters = fdJSON.Parameters
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -179,7 +249,7 @@ func (c *Client) QueryDomain(d *ForemanDomain) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -209,3 +279,27 @@ func (c *Client) QueryDomain(d *ForemanDomain) (QueryResponse, error) {
 
 	return queryResponse, nil
 }
+
+// SearchDomains queries for the ForemanDomains matching the supplied Foreman scoped
+// search expression, ordered by the supplied order expression, and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// domains.
+func (c *Client) SearchDomains(search string, order string) (QueryResponse, error) {
+	log.Tracef("foreman/api/domain.go#SearchDomains")
+
+	results := []ForemanDomain{}
+	queryResponse, searchErr := c.search(DomainEndpointPrefix, search, order, &results)
+	if searchErr != nil {
+		return queryResponse, searchErr
+	}
+
+	// convert the search results from []ForemanDomain to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
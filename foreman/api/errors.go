@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// API Error Model
+// ----------------------------------------------------------------------------
+
+// APIError is returned by the client when the Foreman API responds with a
+// status code outside of the 2xx range.  The error body returned by Foreman is
+// parsed so callers can react to the kind of error (ie: remove an object from
+// the state when it could not be found) and report validation errors.
+type APIError struct {
+	// HTTP status code of the server's response
+	StatusCode int
+	// HTTP method of the request
+	Method string
+	// URL of the request
+	Endpoint string
+	// Error message returned by Foreman, if any
+	Message string
+	// Human readable validation messages returned by Foreman.  Foreman
+	// returns these for 422 Unprocessable Entity responses in the form of:
+	// "Name has already been taken"
+	FullMessages []string
+	// Validation errors returned by Foreman keyed by the name of the
+	// attribute that failed validation
+	Errors map[string][]string
+	// Raw response body of the server
+	RespBody []byte
+}
+
+// foremanErrorJSON struct used for JSON decode of the error body.  Depending
+// on the endpoint and the kind of error, Foreman either nests the details in
+// an "error" object or returns a top-level "message".
+type foremanErrorJSON struct {
+	Message string `json:"message"`
+	Error   struct {
+		Message      string          `json:"message"`
+		FullMessages []string        `json:"full_messages"`
+		Errors       json.RawMessage `json:"errors"`
+	} `json:"error"`
+}
+
+// newAPIError creates an APIError for the request from the status code and
+// response body returned by the server.  Bodies which cannot be decoded are
+// kept as the raw response body only.
+func newAPIError(req *http.Request, statusCode int, respBody []byte) *APIError {
+	apiErr := APIError{
+		StatusCode: statusCode,
+		Method:     req.Method,
+		Endpoint:   req.URL.String(),
+		RespBody:   respBody,
+	}
+
+	var errJSON foremanErrorJSON
+	if json.Unmarshal(respBody, &errJSON) != nil {
+		return &apiErr
+	}
+
+	apiErr.Message = errJSON.Error.Message
+	if apiErr.Message == "" {
+		apiErr.Message = errJSON.Message
+	}
+	apiErr.FullMessages = errJSON.Error.FullMessages
+
+	// NOTE(ALL): "errors" is not always a map of attribute to messages (some
+	//   endpoints return a list of strings) - only keep the map form.
+	var errorsMap map[string][]string
+	if json.Unmarshal(errJSON.Error.Errors, &errorsMap) == nil {
+		apiErr.Errors = errorsMap
+	}
+
+	return &apiErr
+}
+
+// Error implements the error interface.  The message contains the request,
+// the status code and the most descriptive error details Foreman returned.
+func (e *APIError) Error() string {
+	details := ""
+	switch {
+	case len(e.FullMessages) > 0:
+		details = strings.Join(e.FullMessages, "; ")
+	case e.Message != "":
+		details = e.Message
+	default:
+		details = string(e.RespBody)
+	}
+
+	return fmt.Sprintf(
+		"Foreman API error: [%s %s] returned [%d %s]: %s",
+		e.Method,
+		e.Endpoint,
+		e.StatusCode,
+		http.StatusText(e.StatusCode),
+		details,
+	)
+}
+
+// IsNotFound returns true if the error is an APIError for a 404 Not Found
+// response.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnprocessable returns true if the error is an APIError for a 422
+// Unprocessable Entity response.  Foreman responds with 422 when the
+// submitted object failed validation.
+func IsUnprocessable(err error) bool {
+	return hasStatusCode(err, http.StatusUnprocessableEntity)
+}
+
+// IsUnauthorized returns true if the error is an APIError for a 401
+// Unauthorized or 403 Forbidden response.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized) ||
+		hasStatusCode(err, http.StatusForbidden)
+}
+
+// hasStatusCode returns true if the error is (or wraps) an APIError with the
+// supplied status code.
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
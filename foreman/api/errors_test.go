@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Client.SendAndParse error model
+// ----------------------------------------------------------------------------
+
+// Ensure SendAndParse() returns an *APIError carrying the request and the
+// parsed validation errors when the server responds with 422
+func TestSendAndParse_APIErrorUnprocessable(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[POST] /foo' endpoint - returns 422 with validation errors
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(
+			w,
+			`{"error":{"id":null,"errors":{"name":["has already been taken"]},`+
+				`"full_messages":["Name has already been taken"]}}`,
+		)
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "/foo", nil)
+	sendErr := client.SendAndParse(req, nil)
+
+	apiErr, ok := sendErr.(*APIError)
+	if !ok {
+		t.Fatalf(
+			"Client.SendAndParse() did not return an *APIError. Got [%T]",
+			sendErr,
+		)
+	}
+
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Method != http.MethodPost {
+		t.Errorf(
+			"APIError does not describe the request. Expected [%d %s], "+
+				"got [%d %s]",
+			http.StatusUnprocessableEntity,
+			http.MethodPost,
+			apiErr.StatusCode,
+			apiErr.Method,
+		)
+	}
+
+	expectedFullMessages := []string{"Name has already been taken"}
+	if !reflect.DeepEqual(apiErr.FullMessages, expectedFullMessages) {
+		t.Errorf(
+			"APIError full messages differ. Expected [%v], got [%v]",
+			expectedFullMessages,
+			apiErr.FullMessages,
+		)
+	}
+
+	expectedErrors := map[string][]string{"name": []string{"has already been taken"}}
+	if !reflect.DeepEqual(apiErr.Errors, expectedErrors) {
+		t.Errorf(
+			"APIError errors differ. Expected [%v], got [%v]",
+			expectedErrors,
+			apiErr.Errors,
+		)
+	}
+
+	if !IsUnprocessable(sendErr) || IsNotFound(sendErr) {
+		t.Errorf("IsUnprocessable()/IsNotFound() misclassified a 422 response")
+	}
+}
+
+// Ensure SendAndParse() returns an *APIError recognized by IsNotFound() when
+// the server responds with 404
+func TestSendAndParse_APIErrorNotFound(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - returns 404 with a top-level message
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Resource foo not found by id '1'"}`)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	sendErr := client.SendAndParse(req, nil)
+
+	if !IsNotFound(sendErr) || IsUnprocessable(sendErr) {
+		t.Fatalf(
+			"IsNotFound()/IsUnprocessable() misclassified a 404 response. "+
+				"Error: [%v]",
+			sendErr,
+		)
+	}
+
+	apiErr := sendErr.(*APIError)
+	expectedMessage := "Resource foo not found by id '1'"
+	if apiErr.Message != expectedMessage {
+		t.Errorf(
+			"APIError message differs. Expected [%s], got [%s]",
+			expectedMessage,
+			apiErr.Message,
+		)
+	}
+}
+
+// Ensure the error helpers do not match errors which are not API errors
+func TestIsNotFound_NonAPIError(t *testing.T) {
+	err := fmt.Errorf("Resource not found")
+	if IsNotFound(err) || IsUnprocessable(err) || IsUnauthorized(err) {
+		t.Errorf("Error helpers matched an error which is not an *APIError")
+	}
+	if IsNotFound(nil) {
+		t.Errorf("IsNotFound() matched a nil error")
+	}
+}
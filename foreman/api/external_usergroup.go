@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	ExternalUsergroupEndpointPrefix = "/usergroups/%d/external_usergroups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanExternalUsergroup API model represents the mapping of a group of
+// an authentication source, ie: an LDAP group, to a usergroup.  Foreman keeps
+// the members of the usergroup in sync with the members of the external
+// group.  External usergroups are nested under their usergroup in the API.
+type ForemanExternalUsergroup struct {
+	// Inherits the base object's attributes.  The name is the name of the
+	// group in the authentication source.
+	ForemanObject
+
+	// ID of the usergroup the external group is mapped to
+	UsergroupId int
+	// ID of the authentication source the external group is defined in
+	AuthSourceId int
+}
+
+// ForemanExternalUsergroup struct used for JSON decode.  Depending on the
+// Foreman version, the authentication source is returned as either
+// "auth_source_ldap" or "auth_source".  We are only interested in the ID.
+type foremanExternalUsergroupJSON struct {
+	AuthSourceLdap *ForemanObject `json:"auth_source_ldap"`
+	AuthSource     *ForemanObject `json:"auth_source"`
+}
+
+// Implement the Marshaler interface
+func (fe ForemanExternalUsergroup) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/external_usergroup.go#MarshalJSON")
+
+	// NOTE(ALL): the usergroup is part of the endpoint, it is not sent in the
+	//   request data
+
+	feMap := map[string]interface{}{}
+
+	feMap["name"] = fe.Name
+	feMap["auth_source_id"] = intIdToJSONString(fe.AuthSourceId)
+
+	log.Debugf("feMap: [%v]", feMap)
+
+	return json.Marshal(feMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanExternalUsergroup struct.
+func (fe *ForemanExternalUsergroup) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/external_usergroup.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fe.ForemanObject = obj
+
+	// decode special JSON struct for keys that changed names
+	var feJSON foremanExternalUsergroupJSON
+	jsonDecErr = json.Unmarshal(b, &feJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if feJSON.AuthSourceLdap != nil {
+		fe.AuthSourceId = feJSON.AuthSourceLdap.Id
+	} else if feJSON.AuthSource != nil {
+		fe.AuthSourceId = feJSON.AuthSource.Id
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateExternalUsergroup creates a new ForemanExternalUsergroup with the
+// attributes of the supplied ForemanExternalUsergroup reference and returns
+// the created ForemanExternalUsergroup reference.  The returned reference
+// will have its ID and other API default values set by this function.
+func (c *Client) CreateExternalUsergroup(e *ForemanExternalUsergroup) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/external_usergroup.go#Create")
+
+	reqEndpoint := fmt.Sprintf(ExternalUsergroupEndpointPrefix, e.UsergroupId)
+
+	externalUsergroupJSONBytes, jsonEncErr := WrapJson("external_usergroup", e)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("externalUsergroupJSONBytes: [%s]", externalUsergroupJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(externalUsergroupJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdExternalUsergroup ForemanExternalUsergroup
+	sendErr := c.SendAndParse(req, &createdExternalUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	createdExternalUsergroup.UsergroupId = e.UsergroupId
+
+	log.Debugf("createdExternalUsergroup: [%+v]", createdExternalUsergroup)
+
+	return &createdExternalUsergroup, nil
+}
+
+// ReadExternalUsergroup reads the attributes of a ForemanExternalUsergroup
+// identified by the supplied usergroup ID and ID and returns a
+// ForemanExternalUsergroup reference.
+func (c *Client) ReadExternalUsergroup(usergroupId int, id int) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/external_usergroup.go#Read")
+
+	reqEndpoint := fmt.Sprintf(ExternalUsergroupEndpointPrefix+"/%d", usergroupId, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readExternalUsergroup ForemanExternalUsergroup
+	sendErr := c.SendAndParse(req, &readExternalUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	readExternalUsergroup.UsergroupId = usergroupId
+
+	log.Debugf("readExternalUsergroup: [%+v]", readExternalUsergroup)
+
+	return &readExternalUsergroup, nil
+}
+
+// UpdateExternalUsergroup updates a ForemanExternalUsergroup's attributes.
+// The external usergroup with the usergroup ID and ID of the supplied
+// ForemanExternalUsergroup will be updated.  A new ForemanExternalUsergroup
+// reference is returned with the attributes from the result of the update
+// operation.
+func (c *Client) UpdateExternalUsergroup(e *ForemanExternalUsergroup) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/external_usergroup.go#Update")
+
+	reqEndpoint := fmt.Sprintf(ExternalUsergroupEndpointPrefix+"/%d", e.UsergroupId, e.Id)
+
+	externalUsergroupJSONBytes, jsonEncErr := WrapJson("external_usergroup", e)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("externalUsergroupJSONBytes: [%s]", externalUsergroupJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(externalUsergroupJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedExternalUsergroup ForemanExternalUsergroup
+	sendErr := c.SendAndParse(req, &updatedExternalUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	updatedExternalUsergroup.UsergroupId = e.UsergroupId
+
+	log.Debugf("updatedExternalUsergroup: [%+v]", updatedExternalUsergroup)
+
+	return &updatedExternalUsergroup, nil
+}
+
+// DeleteExternalUsergroup deletes the ForemanExternalUsergroup identified by
+// the supplied usergroup ID and ID
+func (c *Client) DeleteExternalUsergroup(usergroupId int, id int) error {
+	log.Tracef("foreman/api/external_usergroup.go#Delete")
+
+	reqEndpoint := fmt.Sprintf(ExternalUsergroupEndpointPrefix+"/%d", usergroupId, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
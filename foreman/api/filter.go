@@ -0,0 +1,302 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	FilterEndpointPrefix = "filters"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanFilter API model represents a filter of a role.  A filter grants a
+// set of permissions on a single resource type.  The objects the permissions
+// apply to can be limited by a scoped search and, if the filter overrides the
+// role's taxonomies, by locations and organizations.
+type ForemanFilter struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the role the filter belongs to
+	RoleId int
+	// Scoped search limiting the objects the permissions apply to
+	Search string
+	// Whether or not the filter overrides the role's taxonomies
+	Override bool
+	// IDs of the permissions granted by the filter
+	PermissionIds []int
+	// IDs of the locations and organizations the filter is limited to if it
+	// overrides the role's taxonomies
+	LocationIds     []int
+	OrganizationIds []int
+
+	// NOTE(ALL): The following attributes are computed by Foreman from the
+	//   filter's permissions and search and are never sent to Foreman
+	// Resource type of the filter's permissions
+	ResourceType string
+	// Whether or not the filter applies to all objects (no search)
+	Unlimited bool
+}
+
+// ForemanFilter struct used for JSON decode.  Foreman API returns the associated
+// objects as ForemanObjects.  However, we are only interested in the IDs
+// returned.
+type foremanFilterJSON struct {
+	Search        string          `json:"search"`
+	Override      bool            `json:"override"`
+	ResourceType  string          `json:"resource_type"`
+	Unlimited     bool            `json:"unlimited"`
+	Role          *ForemanObject  `json:"role"`
+	Permissions   []ForemanObject `json:"permissions"`
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+}
+
+// Implement the Marshaler interface
+func (ff ForemanFilter) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/filter.go#MarshalJSON")
+
+	// NOTE(ALL): omit the "resource_type" and "unlimited" properties from the
+	//   JSON marshal since they are computed values
+
+	ffMap := map[string]interface{}{}
+
+	ffMap["role_id"] = intIdToJSONString(ff.RoleId)
+	ffMap["search"] = ff.Search
+	ffMap["override"] = ff.Override
+	ffMap["permission_ids"] = ff.PermissionIds
+
+	// NOTE(ALL): A nil list of taxonomies was not configured and is left out
+	//   so the existing taxonomies are kept, an empty list removes them.
+	if ff.LocationIds != nil {
+		ffMap["location_ids"] = ff.LocationIds
+	}
+	if ff.OrganizationIds != nil {
+		ffMap["organization_ids"] = ff.OrganizationIds
+	}
+
+	log.Debugf("ffMap: [%v]", ffMap)
+
+	return json.Marshal(ffMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct and
+// then convert over to a ForemanFilter struct.
+func (ff *ForemanFilter) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/filter.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	ff.ForemanObject = fo
+
+	// decode special JSON struct for keys that changed names
+	var ffJSON foremanFilterJSON
+	jsonDecErr = json.Unmarshal(b, &ffJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	ff.Search = ffJSON.Search
+	ff.Override = ffJSON.Override
+	ff.ResourceType = ffJSON.ResourceType
+	ff.Unlimited = ffJSON.Unlimited
+	if ffJSON.Role != nil {
+		ff.RoleId = ffJSON.Role.Id
+	}
+	ff.PermissionIds = foremanObjectArrayToIdIntArray(ffJSON.Permissions)
+	ff.LocationIds = foremanObjectArrayToIdIntArray(ffJSON.Locations)
+	ff.OrganizationIds = foremanObjectArrayToIdIntArray(ffJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateFilter creates a new ForemanFilter with the attributes of the supplied
+// ForemanFilter reference and returns the created ForemanFilter reference.  The
+// returned reference will have its ID and other API default values set by this
+// function.
+func (c *Client) CreateFilter(f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", FilterEndpointPrefix)
+
+	filterJSONBytes, jsonEncErr := WrapJson("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &createdFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdFilter: [%+v]", createdFilter)
+
+	return &createdFilter, nil
+}
+
+// ReadFilter reads the attributes of a ForemanFilter identified by the supplied
+// ID and returns a ForemanFilter reference.
+func (c *Client) ReadFilter(id int) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &readFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readFilter: [%+v]", readFilter)
+
+	return &readFilter, nil
+}
+
+// UpdateFilter updates a ForemanFilter's attributes.  The filter with the ID of
+// the supplied ForemanFilter will be updated. A new ForemanFilter reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateFilter(f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, f.Id)
+
+	filterJSONBytes, jsonEncErr := WrapJson("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &updatedFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedFilter: [%+v]", updatedFilter)
+
+	return &updatedFilter, nil
+}
+
+// DeleteFilter deletes the ForemanFilter identified by the supplied ID
+func (c *Client) DeleteFilter(id int) error {
+	log.Tracef("foreman/api/filter.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryFilter queries for a ForemanFilter based on the attributes of the
+// supplied ForemanFilter reference and returns a QueryResponse struct
+// containing query/response metadata and the matching filters.
+func (c *Client) QueryFilter(f *ForemanFilter) (QueryResponse, error) {
+	log.Tracef("foreman/api/filter.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", FilterEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	reqQuery.Set("search", fmt.Sprintf("role_id=%d", f.RoleId))
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanFilter for
+	// the results
+	results := []ForemanFilter{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanFilter to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
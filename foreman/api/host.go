@@ -37,6 +37,19 @@ const (
 	PowerBios = "bios"
 )
 
+// Build status values reported by Foreman for a host
+const (
+	// HostBuildStatusBuilt : The host finished its installation
+	HostBuildStatusBuilt = 0
+	// HostBuildStatusPending : The host is waiting for its installation
+	HostBuildStatusPending = 1
+	// HostBuildStatusTokenExpired : The build token expired before the
+	// installation finished
+	HostBuildStatusTokenExpired = 2
+	// HostBuildStatusFailed : The installation of the host failed
+	HostBuildStatusFailed = 3
+)
+
 // -----------------------------------------------------------------------------
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
@@ -84,6 +97,29 @@ type ForemanHost struct {
 	ComputeProfileId int `json:"compute_profile_id,omitempty"`
 	// LocationID specifies the location of the host
 	LocationId int `json:"location_id"`
+	// OrganizationId specifies the organization of the host
+	OrganizationId int `json:"organization_id"`
+
+	// NOTE(ALL): The following attributes are reported by Foreman and are
+	//   never sent as part of a create or update request
+	// Build status of the host (0 = built, 1 = pending installation,
+	// 2 = token expired, 3 = build failed)
+	BuildStatus int `json:"build_status"`
+	// Human readable form of the build status
+	BuildStatusLabel string `json:"build_status_label"`
+	// Time the host finished its installation
+	InstalledAt string `json:"installed_at"`
+	// Time of the last report Foreman received from the host
+	LastReport string `json:"last_report"`
+	// Names of the hostgroup, operating system and environment of the host
+	HostgroupName       string `json:"hostgroup_name"`
+	OperatingSystemName string `json:"operatingsystem_name"`
+	EnvironmentName     string `json:"environment_name"`
+	// Names of the compute resource and compute profile of the host
+	ComputeResourceName string `json:"compute_resource_name"`
+	ComputeProfileName  string `json:"compute_profile_name"`
+	// UUID of the host's virtual machine on the compute resource
+	Uuid string `json:"uuid"`
 }
 
 // ForemanInterfacesAttribute representing a hosts defined network interfaces
@@ -125,6 +161,7 @@ type ForemanInterfacesAttribute struct {
 // foremanHostJSON struct used for JSON decode.
 type foremanHostJSON struct {
 	InterfacesAttributes []ForemanInterfacesAttribute `json:"interfaces"`
+	Parameters           []ForemanKVParameter         `json:"parameters"`
 }
 
 // Power struct for marshal/unmarshal of power state
@@ -167,6 +204,7 @@ func (fh ForemanHost) MarshalJSON() ([]byte, error) {
 	fhMap["compute_resource_id"] = intIdToJSONString(fh.ComputeResourceId)
 	fhMap["compute_profile_id"] = intIdToJSONString(fh.ComputeProfileId)
 	fhMap["location_id"] = intIdToJSONString(fh.LocationId)
+	fhMap["organization_id"] = intIdToJSONString(fh.OrganizationId)
 	if len(fh.InterfacesAttributes) > 0 {
 		fhMap["interfaces_attributes"] = fh.InterfacesAttributes
 	}
@@ -199,6 +237,7 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 		return jsonDecErr
 	}
 	fh.InterfacesAttributes = fhJSON.InterfacesAttributes
+	fh.HostParameters = fhJSON.Parameters
 
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
@@ -213,7 +252,8 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 	if fh.Build, ok = fhMap["build"].(bool); !ok {
 		fh.Build = false
 	}
-	if fh.Method, ok = fhMap["method"].(string); !ok {
+	// NOTE(ALL): Foreman reports the provision method as "provision_method"
+	if fh.Method, ok = fhMap["provision_method"].(string); !ok || fh.Method == "" {
 		fh.Method = "build"
 	}
 	if fh.Comment, ok = fhMap["comment"].(string); !ok {
@@ -222,9 +262,34 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 	if fh.DomainName, ok = fhMap["domain_name"].(string); !ok {
 		fh.DomainName = ""
 	}
-	if fh.HostParameters, ok = fhMap["host_parameters_attributes"].([]ForemanKVParameter); !ok {
-		fh.HostParameters = []ForemanKVParameter{}
+	if fh.BuildStatusLabel, ok = fhMap["build_status_label"].(string); !ok {
+		fh.BuildStatusLabel = ""
+	}
+	if fh.InstalledAt, ok = fhMap["installed_at"].(string); !ok {
+		fh.InstalledAt = ""
+	}
+	if fh.LastReport, ok = fhMap["last_report"].(string); !ok {
+		fh.LastReport = ""
+	}
+	if fh.HostgroupName, ok = fhMap["hostgroup_name"].(string); !ok {
+		fh.HostgroupName = ""
+	}
+	if fh.OperatingSystemName, ok = fhMap["operatingsystem_name"].(string); !ok {
+		fh.OperatingSystemName = ""
 	}
+	if fh.EnvironmentName, ok = fhMap["environment_name"].(string); !ok {
+		fh.EnvironmentName = ""
+	}
+	if fh.ComputeResourceName, ok = fhMap["compute_resource_name"].(string); !ok {
+		fh.ComputeResourceName = ""
+	}
+	if fh.ComputeProfileName, ok = fhMap["compute_profile_name"].(string); !ok {
+		fh.ComputeProfileName = ""
+	}
+	if fh.Uuid, ok = fhMap["uuid"].(string); !ok {
+		fh.Uuid = ""
+	}
+	fh.BuildStatus = unmarshalInteger(fhMap["build_status"])
 
 	// Unmarshal the remaining foreign keys to their id
 	fh.DomainId = unmarshalInteger(fhMap["domain_id"])
@@ -238,10 +303,13 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 	fh.ComputeResourceId = unmarshalInteger(fhMap["compute_resource_id"])
 	fh.ComputeProfileId = unmarshalInteger(fhMap["compute_profile_id"])
 	fh.LocationId = unmarshalInteger(fhMap["location_id"])
+	fh.OrganizationId = unmarshalInteger(fhMap["organization_id"])
 
-	// Foreman returns FQDN as Name but doesnt accept it as Name in return. Great times
-	if fh.DomainName != "" && strings.Contains(fh.ForemanObject.Name, fh.DomainName) {
-		fh.ForemanObject.Name = strings.Replace(fh.ForemanObject.Name, "."+fh.DomainName, "", 1)
+	// NOTE(ALL): Foreman returns the FQDN of a host as its name.  Only the short
+	//   name is kept, which is what Foreman expects in a request.  Names which
+	//   do not end with the domain are left unchanged.
+	if fh.DomainName != "" {
+		fh.ForemanObject.Name = strings.TrimSuffix(fh.ForemanObject.Name, "."+fh.DomainName)
 	}
 
 	return nil
@@ -252,7 +320,7 @@ func (fh *ForemanHost) UnmarshalJSON(b []byte) error {
 // BMCBoot type struct populated with an action
 //
 // Example: https://<foreman>/api/hosts/<hostname>/boot
-func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount int) error {
+func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}) error {
 	// Initialize suffix variable,
 	suffix := ""
 
@@ -280,20 +348,7 @@ func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount in
 		return reqErr
 	}
 
-	retry := 0
-	var sendErr error
-	// retry until the successful Operation
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("SendPower: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &cmd)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &cmd)
 	if sendErr != nil {
 		return sendErr
 	}
@@ -311,6 +366,60 @@ func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount in
 	return nil
 }
 
+// ReadPowerState returns the power state of the host ("on" or "off") as
+// reported by the host's BMC or compute resource.  The state reported by a
+// compute resource is normalized, see normalizePowerState().
+//
+// Example: https://<foreman>/api/hosts/<hostname>/power
+func (c *Client) ReadPowerState(h *ForemanHost) (string, error) {
+	log.Tracef("foreman/api/host.go#ReadPowerState")
+
+	reqHost := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, h.Id, PowerSuffix)
+
+	JSONBytes, jsonEncErr := json.Marshal(Power{PowerAction: PowerState})
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+
+	req, reqErr := c.NewRequest(http.MethodPut, reqHost, bytes.NewBuffer(JSONBytes))
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	// NOTE(ALL): Foreman responds with the state as a string in the "power"
+	//   field, unlike the boolean result of the other power actions
+	var stateResp map[string]interface{}
+	sendErr := c.SendAndParse(req, &stateResp)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	log.Debugf("Power State Response: [%+v]", stateResp)
+
+	state, ok := stateResp[PowerSuffix].(string)
+	if !ok {
+		return "", fmt.Errorf("Failed to read the power state of host [%d]", h.Id)
+	}
+	return normalizePowerState(state)
+}
+
+// normalizePowerState maps the power state reported by a BMC or compute
+// resource to "on" or "off".
+//
+// NOTE(ALL): The state of a host on a compute resource is the raw state of
+//   the VM, ie: "running" for libvirt, "poweredOn" for VMware or "stopped"
+//   for EC2.  Like Foreman, a VM which is not ready is considered off.
+func normalizePowerState(state string) (string, error) {
+	switch strings.ToLower(state) {
+	case "on", "running", "poweredon", "up", "active":
+		return PowerOn, nil
+	case "off", "stopped", "shutoff", "poweredoff", "down", "shutdown",
+		"terminated", "suspended", "paused":
+		return PowerOff, nil
+	}
+	return "", fmt.Errorf("Unknown power state [%s]", state)
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -319,7 +428,7 @@ func (c *Client) SendPowerCommand(h *ForemanHost, cmd interface{}, retryCount in
 // ForemanHost reference and returns the created ForemanHost reference.  The
 // returned reference will have its ID and other API default values set by this
 // function.
-func (c *Client) CreateHost(h *ForemanHost, retryCount int) (*ForemanHost, error) {
+func (c *Client) CreateHost(h *ForemanHost) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#Create")
 
 	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
@@ -342,20 +451,7 @@ func (c *Client) CreateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 
 	var createdHost ForemanHost
 
-	retry := 0
-	var sendErr error
-	// retry until successful Host creation
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("CreatedHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &createdHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &createdHost)
 	if sendErr != nil {
 		return nil, sendErr
 	}
@@ -395,14 +491,15 @@ func (c *Client) ReadHost(id int) (*ForemanHost, error) {
 // UpdateHost updates a ForemanHost's attributes.  The host with the ID of the
 // supplied ForemanHost will be updated. A new ForemanHost reference is
 // returned with the attributes from the result of the update operation.
-func (c *Client) UpdateHost(h *ForemanHost, retryCount int) (*ForemanHost, error) {
+//
+// The supplied interfaces are updated by ID, interfaces without an ID are
+// added and interfaces with the Destroy flag are removed from the host.
+// Interfaces not supplied are left untouched.
+func (c *Client) UpdateHost(h *ForemanHost) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#Update")
 
 	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, h.Id)
 
-	// Cannot update interfaces in-place. And causes errors if the object is set
-	h.InterfacesAttributes = nil
-
 	hJSONBytes, jsonEncErr := WrapJson("host", h)
 	if jsonEncErr != nil {
 		return nil, jsonEncErr
@@ -420,20 +517,7 @@ func (c *Client) UpdateHost(h *ForemanHost, retryCount int) (*ForemanHost, error
 	}
 
 	var updatedHost ForemanHost
-	retry := 0
-	var sendErr error
-	// retry until the successful Host Update
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("UpdateHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &updatedHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &updatedHost)
 	if sendErr != nil {
 		return nil, sendErr
 	}
@@ -460,3 +544,31 @@ func (c *Client) DeleteHost(id int) error {
 
 	return c.SendAndParse(req, nil)
 }
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// SearchHosts queries for the ForemanHosts matching the supplied Foreman scoped
+// search expression, ordered by the supplied order expression, and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// hosts.
+func (c *Client) SearchHosts(search string, order string) (QueryResponse, error) {
+	log.Tracef("foreman/api/host.go#SearchHosts")
+
+	results := []ForemanHost{}
+	queryResponse, searchErr := c.search(HostEndpointPrefix, search, order, &results)
+	if searchErr != nil {
+		return queryResponse, searchErr
+	}
+
+	// convert the search results from []ForemanHost to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -64,11 +64,20 @@ type ForemanHostgroup struct {
 	SubnetId int `json:"subnet_id"`
 	// Default PXELoader for the hostgroup
 	PXELoader string `json:"pxe_loader,omitempty"`
+	// ID of the organization the hostgroup belongs to
+	OrganizationId int `json:"organization_id"`
 
 	// Map of HostGroupParameters
 	HostGroupParameters []ForemanKVParameter `json:"group_parameters_attributes,omitempty"`
 }
 
+// ForemanHostgroup struct used for JSON decode.  Foreman API returns the
+// organizations of a hostgroup as a list of ForemanObjects.
+type foremanHostgroupJSON struct {
+	Organizations []ForemanObject      `json:"organizations"`
+	Parameters    []ForemanKVParameter `json:"parameters"`
+}
+
 // Implement the Marshaler interface
 func (fh ForemanHostgroup) MarshalJSON() ([]byte, error) {
 	log.Tracef("foreman/api/hostgroup.go#MarshalJSON")
@@ -95,6 +104,8 @@ func (fh ForemanHostgroup) MarshalJSON() ([]byte, error) {
 	fhMap["realm_id"] = intIdToJSONString(fh.RealmId)
 	fhMap["subnet_id"] = intIdToJSONString(fh.SubnetId)
 
+	setOrganizationIds(fhMap, fh.OrganizationId)
+
 	if len(fh.HostGroupParameters) > 0 {
 		fhMap["group_parameters_attributes"] = fh.HostGroupParameters
 	}
@@ -115,6 +126,18 @@ func (fh *ForemanHostgroup) UnmarshalJSON(b []byte) error {
 	}
 	fh.ForemanObject = fo
 
+	// Unmarshal to temporary JSON struct to get the properties with differently
+	// named keys
+	var fhJSON foremanHostgroupJSON
+	jsonDecErr = json.Unmarshal(b, &fhJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if orgIds := foremanObjectArrayToIdIntArray(fhJSON.Organizations); len(orgIds) > 0 {
+		fh.OrganizationId = orgIds[0]
+	}
+	fh.HostGroupParameters = fhJSON.Parameters
+
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	var fhMap map[string]interface{}
 	jsonDecErr = json.Unmarshal(b, &fhMap)
@@ -122,15 +145,15 @@ func (fh *ForemanHostgroup) UnmarshalJSON(b []byte) error {
 		return jsonDecErr
 	}
 	var ok bool
+	if fh.Title, ok = fhMap["title"].(string); !ok {
+		fh.Title = ""
+	}
 	if fh.RootPassword, ok = fhMap["root_password"].(string); !ok {
 		fh.RootPassword = ""
 	}
 	if fh.PXELoader, ok = fhMap["pxe_loader"].(string); !ok {
 		fh.PXELoader = ""
 	}
-	if fh.HostGroupParameters, ok = fhMap["group_parameters_attributes"].([]ForemanKVParameter); !ok {
-		fh.HostGroupParameters = []ForemanKVParameter{}
-	}
 
 	// Unmarshal the remaining foreign keys to their id
 	fh.ArchitectureId = unmarshalInteger(fhMap["architecture_id"])
@@ -298,7 +321,7 @@ func (c *Client) QueryHostgroup(h *ForemanHostgroup) (QueryResponse, error) {
 	reqQuery.Set("search", "title="+title)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -328,3 +351,27 @@ func (c *Client) QueryHostgroup(h *ForemanHostgroup) (QueryResponse, error) {
 
 	return queryResponse, nil
 }
+
+// SearchHostgroups queries for the ForemanHostgroups matching the supplied Foreman scoped
+// search expression, ordered by the supplied order expression, and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// hostgroups.
+func (c *Client) SearchHostgroups(search string, order string) (QueryResponse, error) {
+	log.Tracef("foreman/api/hostgroup.go#SearchHostgroups")
+
+	results := []ForemanHostgroup{}
+	queryResponse, searchErr := c.search(HostgroupEndpointPrefix, search, order, &results)
+	if searchErr != nil {
+		return queryResponse, searchErr
+	}
+
+	// convert the search results from []ForemanHostgroup to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
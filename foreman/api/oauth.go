@@ -0,0 +1,129 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// OAuth 1.0a signature method supported by Foreman
+	OAUTH_SIGNATURE_METHOD = "HMAC-SHA1"
+	// OAuth protocol version sent with every signed request
+	OAUTH_VERSION = "1.0"
+	// Header Foreman reads to map an OAuth request to a Foreman user when
+	// 'oauth_map_users' is enabled in Foreman's settings
+	OAUTH_FOREMAN_USER_HEADER = "FOREMAN-USER"
+)
+
+// ----------------------------------------------------------------------------
+// OAuth 1.0a Request Signing
+// ----------------------------------------------------------------------------
+
+// signOAuthRequest signs the request with the client's OAuth consumer key and
+// secret and sets the resulting Authorization header.  Foreman uses two-legged
+// OAuth, so requests are signed without a token.  A fresh nonce and timestamp
+// are generated on every call, so the request must be signed again before
+// each attempt to send it.
+//
+// If the client has a username, it is sent in the FOREMAN-USER header so
+// Foreman can map the request to that user.
+//
+// SEE: RFC 5849
+func (client *Client) signOAuthRequest(req *http.Request) error {
+	nonce := make([]byte, 16)
+	if _, randErr := rand.Read(nonce); randErr != nil {
+		return randErr
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     client.credentials.OAuthConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": OAUTH_SIGNATURE_METHOD,
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          OAUTH_VERSION,
+	}
+	oauthParams["oauth_signature"] = oauthSignature(
+		oauthBaseString(req.Method, req.URL, oauthParams),
+		client.credentials.OAuthConsumerSecret,
+	)
+
+	keys := make([]string, 0, len(oauthParams))
+	for key := range oauthParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	authParams := make([]string, 0, len(keys))
+	for _, key := range keys {
+		authParams = append(
+			authParams,
+			oauthEscape(key)+"=\""+oauthEscape(oauthParams[key])+"\"",
+		)
+	}
+
+	req.Header.Set("Authorization", "OAuth "+strings.Join(authParams, ", "))
+	if client.credentials.Username != "" {
+		req.Header.Set(OAUTH_FOREMAN_USER_HEADER, client.credentials.Username)
+	}
+	return nil
+}
+
+// oauthBaseString constructs the signature base string for a request from
+// its method, URL and OAuth protocol parameters.  The query parameters of the
+// URL are part of the signature.  The request body is not, since the client
+// only sends JSON bodies.
+//
+// SEE: RFC 5849, section 3.4.1
+func oauthBaseString(method string, reqURL *url.URL, oauthParams map[string]string) string {
+	// base string URI - lowercase scheme and host, default ports omitted
+	scheme := strings.ToLower(reqURL.Scheme)
+	host := strings.ToLower(reqURL.Host)
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) ||
+		(scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	baseURI := scheme + "://" + host + reqURL.EscapedPath()
+
+	// normalized parameters - encoded, sorted by name and then value
+	params := []string{}
+	for key, values := range reqURL.Query() {
+		for _, value := range values {
+			params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+		}
+	}
+	for key, value := range oauthParams {
+		params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+	}
+	sort.Strings(params)
+
+	return strings.ToUpper(method) + "&" +
+		oauthEscape(baseURI) + "&" +
+		oauthEscape(strings.Join(params, "&"))
+}
+
+// oauthSignature computes the HMAC-SHA1 signature of the base string.  The
+// signing key is made of the consumer secret and an empty token secret.
+//
+// SEE: RFC 5849, section 3.4.2
+func oauthSignature(baseString string, consumerSecret string) string {
+	mac := hmac.New(sha1.New, []byte(oauthEscape(consumerSecret)+"&"))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oauthEscape percent-encodes a value as required by OAuth.  Only unreserved
+// characters are left unencoded and spaces are encoded as "%20".
+//
+// SEE: RFC 5849, section 3.6
+func oauthEscape(value string) string {
+	return strings.Replace(url.QueryEscape(value), "+", "%20", -1)
+}
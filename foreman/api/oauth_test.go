@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// OAuth 1.0a request signing
+// ----------------------------------------------------------------------------
+
+// Ensure the signature base string normalizes the URL and includes the query
+// and OAuth parameters, sorted and encoded
+func TestOAuthBaseString(t *testing.T) {
+	reqURL, _ := url.Parse(
+		"HTTP://Foreman.example.com:80/api/hosts?search=name%20~%20web&page=1",
+	)
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     "key",
+		"oauth_nonce":            "abc",
+		"oauth_signature_method": OAUTH_SIGNATURE_METHOD,
+		"oauth_timestamp":        "1700000000",
+		"oauth_version":          OAUTH_VERSION,
+	}
+
+	expected := "GET&http%3A%2F%2Fforeman.example.com%2Fapi%2Fhosts&" +
+		"oauth_consumer_key%3Dkey%26oauth_nonce%3Dabc%26" +
+		"oauth_signature_method%3DHMAC-SHA1%26oauth_timestamp%3D1700000000%26" +
+		"oauth_version%3D1.0%26page%3D1%26search%3Dname%2520~%2520web"
+
+	if baseString := oauthBaseString("get", reqURL, oauthParams); baseString != expected {
+		t.Errorf(
+			"oauthBaseString() returned an incorrect base string. Expected [%s], "+
+				"got [%s]",
+			expected,
+			baseString,
+		)
+	}
+
+	expectedSignature := "CUKAc2BfoB2hVMmsEhAdpW2tMCc="
+	if signature := oauthSignature(expected, "sec ret"); signature != expectedSignature {
+		t.Errorf(
+			"oauthSignature() returned an incorrect signature. Expected [%s], "+
+				"got [%s]",
+			expectedSignature,
+			signature,
+		)
+	}
+}
+
+// Ensure requests sent by a client with OAuth credentials carry a valid OAuth
+// Authorization header and the FOREMAN-USER header instead of basic auth
+func TestSend_OAuthSignsRequest(t *testing.T) {
+	cred := ClientCredentials{
+		Username:            "Admin",
+		Password:            "ChangeMe",
+		OAuthConsumerKey:    "key",
+		OAuthConsumerSecret: "secret",
+	}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[GET] /foo' endpoint - verifies the request's signature
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Errorf("OAuth request was sent with basic auth credentials")
+		}
+		if r.Header.Get(OAUTH_FOREMAN_USER_HEADER) != cred.Username {
+			t.Errorf(
+				"OAuth request has an incorrect [%s] header. Expected [%s], got [%s]",
+				OAUTH_FOREMAN_USER_HEADER,
+				cred.Username,
+				r.Header.Get(OAUTH_FOREMAN_USER_HEADER),
+			)
+		}
+
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "OAuth ") {
+			t.Fatalf("OAuth request has no OAuth Authorization header. Got [%s]", authHeader)
+		}
+		oauthParams := map[string]string{}
+		for _, param := range strings.Split(strings.TrimPrefix(authHeader, "OAuth "), ", ") {
+			kv := strings.SplitN(param, "=", 2)
+			value, _ := url.QueryUnescape(strings.Trim(kv[1], "\""))
+			oauthParams[kv[0]] = value
+		}
+		signature := oauthParams["oauth_signature"]
+		delete(oauthParams, "oauth_signature")
+
+		reqURL := *r.URL
+		reqURL.Scheme = "http"
+		reqURL.Host = r.Host
+		expectedSignature := oauthSignature(
+			oauthBaseString(r.Method, &reqURL, oauthParams),
+			cred.OAuthConsumerSecret,
+		)
+		if oauthParams["oauth_consumer_key"] != cred.OAuthConsumerKey || signature != expectedSignature {
+			t.Errorf(
+				"OAuth request has an invalid signature. Expected [%s] for consumer "+
+					"[%s], got [%s] for consumer [%s]",
+				expectedSignature,
+				cred.OAuthConsumerKey,
+				signature,
+				oauthParams["oauth_consumer_key"],
+			)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+	req.URL.RawQuery = "search=name%20%3D%20foo"
+	if sendErr := client.SendAndParse(req, nil); sendErr != nil {
+		t.Errorf("Client.SendAndParse() returned an error: [%v]", sendErr)
+	}
+}
@@ -62,6 +62,8 @@ type foremanOsRespJSON struct {
 	Media                 []ForemanObject `json:"media"`
 	Architectures         []ForemanObject `json:"architectures"`
 	Partitiontables       []ForemanObject `json:"ptables"`
+
+	Parameters []ForemanKVParameter `json:"parameters"`
 }
 
 // Implement the Unmarshaler interface
@@ -85,6 +87,7 @@ func (o *ForemanOperatingSystem) UnmarshalJSON(b []byte) error {
 	o.ArchitectureIds = foremanObjectArrayToIdIntArray(foJSON.Architectures)
 	o.MediumIds = foremanObjectArrayToIdIntArray(foJSON.Media)
 	o.PartitiontableIds = foremanObjectArrayToIdIntArray(foJSON.Partitiontables)
+	o.OperatingSystemParameters = foJSON.Parameters
 
 	var foMap map[string]interface{}
 	jsonDecErr = json.Unmarshal(b, &foMap)
@@ -115,9 +118,6 @@ func (o *ForemanOperatingSystem) UnmarshalJSON(b []byte) error {
 	if o.PasswordHash, ok = foMap["password_hash"].(string); !ok {
 		o.PasswordHash = ""
 	}
-	if o.OperatingSystemParameters, ok = foMap["os_parameters_attributes"].([]ForemanKVParameter); !ok {
-		o.OperatingSystemParameters = []ForemanKVParameter{}
-	}
 
 	return nil
 }
@@ -275,7 +275,7 @@ func (c *Client) QueryOperatingSystem(o *ForemanOperatingSystem) (QueryResponse,
 	reqQuery.Set("search", "title="+title)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -305,3 +305,27 @@ func (c *Client) QueryOperatingSystem(o *ForemanOperatingSystem) (QueryResponse,
 
 	return queryResponse, nil
 }
+
+// SearchOperatingSystems queries for the ForemanOperatingSystems matching the supplied Foreman scoped
+// search expression, ordered by the supplied order expression, and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// operating systems.
+func (c *Client) SearchOperatingSystems(search string, order string) (QueryResponse, error) {
+	log.Tracef("foreman/api/operatingsystem.go#SearchOperatingSystems")
+
+	results := []ForemanOperatingSystem{}
+	queryResponse, searchErr := c.search(OperatingSystemEndpointPrefix, search, order, &results)
+	if searchErr != nil {
+		return queryResponse, searchErr
+	}
+
+	// convert the search results from []ForemanOperatingSystem to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
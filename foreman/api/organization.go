@@ -0,0 +1,332 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	OrganizationEndpointPrefix = "organizations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanOrganization API model represents an organization.  Together
+// with locations, organizations form the taxonomies Foreman uses to separate
+// the objects of different tenants.  Organizations can be nested and inherit
+// from their parent organization.
+type ForemanOrganization struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// The title is a computed property representing the fullname of the
+	// organization.  It is a path-like string from the top of the
+	// organization tree down to this organization in the form of:
+	// "<parent 1>/<parent 2>/.../<name>"
+	Title string
+	// Description of the organization
+	Description string
+	// ID of this organization's parent organization
+	ParentId int
+
+	// IDs of the associated objects.  A nil list is not sent to Foreman.
+	Realms                []int
+	ComputeResources      []int
+	Domains               []int
+	Subnets               []int
+	Environments          []int
+	Hostgroups            []int
+	ProvisioningTemplates []int
+	SmartProxies          []int
+	Users                 []int
+
+	// Map of OrganizationParameters
+	OrganizationParameters []ForemanKVParameter
+}
+
+// ForemanOrganization struct used for JSON decode.  Foreman API returns the
+// associated objects as lists of ForemanObjects.  However, we are only
+// interested in the IDs returned.
+type foremanOrganizationJSON struct {
+	Title                 string               `json:"title"`
+	Description           string               `json:"description"`
+	ParentId              int                  `json:"parent_id"`
+	Realms                []ForemanObject      `json:"realms"`
+	ComputeResources      []ForemanObject      `json:"compute_resources"`
+	Domains               []ForemanObject      `json:"domains"`
+	Subnets               []ForemanObject      `json:"subnets"`
+	Environments          []ForemanObject      `json:"environments"`
+	Hostgroups            []ForemanObject      `json:"hostgroups"`
+	ProvisioningTemplates []ForemanObject      `json:"provisioning_templates"`
+	SmartProxies          []ForemanObject      `json:"smart_proxies"`
+	Users                 []ForemanObject      `json:"users"`
+	Parameters            []ForemanKVParameter `json:"parameters"`
+}
+
+// Implement the Marshaler interface
+func (fo ForemanOrganization) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/organization.go#MarshalJSON")
+
+	// NOTE(ALL): omit the "title" property from the JSON marshal since it is a
+	//   computed value
+
+	foMap := map[string]interface{}{}
+
+	foMap["name"] = fo.Name
+	foMap["description"] = fo.Description
+	foMap["parent_id"] = intIdToJSONString(fo.ParentId)
+
+	// NOTE(ALL): Foreman replaces the associations of the organization with
+	//   every list of IDs it receives.  A nil list was not configured and is
+	//   left out so the existing associations are kept, an empty list removes
+	//   them.
+	idLists := map[string][]int{
+		"realm_ids":                 fo.Realms,
+		"compute_resource_ids":      fo.ComputeResources,
+		"domain_ids":                fo.Domains,
+		"subnet_ids":                fo.Subnets,
+		"environment_ids":           fo.Environments,
+		"hostgroup_ids":             fo.Hostgroups,
+		"provisioning_template_ids": fo.ProvisioningTemplates,
+		"smart_proxy_ids":           fo.SmartProxies,
+		"user_ids":                  fo.Users,
+	}
+	for key, ids := range idLists {
+		if ids != nil {
+			foMap[key] = ids
+		}
+	}
+
+	if len(fo.OrganizationParameters) > 0 {
+		foMap["organization_parameters_attributes"] = fo.OrganizationParameters
+	}
+
+	log.Debugf("foMap: [%v]", foMap)
+
+	return json.Marshal(foMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanOrganization struct.
+func (fo *ForemanOrganization) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/organization.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.ForemanObject = obj
+
+	// decode special JSON struct for keys that changed names
+	var foJSON foremanOrganizationJSON
+	jsonDecErr = json.Unmarshal(b, &foJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.Title = foJSON.Title
+	fo.Description = foJSON.Description
+	fo.ParentId = foJSON.ParentId
+	fo.Realms = foremanObjectArrayToIdIntArray(foJSON.Realms)
+	fo.ComputeResources = foremanObjectArrayToIdIntArray(foJSON.ComputeResources)
+	fo.Domains = foremanObjectArrayToIdIntArray(foJSON.Domains)
+	fo.Subnets = foremanObjectArrayToIdIntArray(foJSON.Subnets)
+	fo.Environments = foremanObjectArrayToIdIntArray(foJSON.Environments)
+	fo.Hostgroups = foremanObjectArrayToIdIntArray(foJSON.Hostgroups)
+	fo.ProvisioningTemplates = foremanObjectArrayToIdIntArray(foJSON.ProvisioningTemplates)
+	fo.SmartProxies = foremanObjectArrayToIdIntArray(foJSON.SmartProxies)
+	fo.Users = foremanObjectArrayToIdIntArray(foJSON.Users)
+	fo.OrganizationParameters = foJSON.Parameters
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateOrganization creates a new ForemanOrganization with the attributes of
+// the supplied ForemanOrganization reference and returns the created
+// ForemanOrganization reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateOrganization(o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", OrganizationEndpointPrefix)
+
+	organizationJSONBytes, jsonEncErr := WrapJson("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", organizationJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(organizationJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &createdOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdOrganization: [%+v]", createdOrganization)
+
+	return &createdOrganization, nil
+}
+
+// ReadOrganization reads the attributes of a ForemanOrganization identified by
+// the supplied ID and returns a ForemanOrganization reference.
+func (c *Client) ReadOrganization(id int) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &readOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readOrganization: [%+v]", readOrganization)
+
+	return &readOrganization, nil
+}
+
+// UpdateOrganization updates a ForemanOrganization's attributes.  The
+// organization with the ID of the supplied ForemanOrganization will be
+// updated. A new ForemanOrganization reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateOrganization(o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, o.Id)
+
+	organizationJSONBytes, jsonEncErr := WrapJson("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", organizationJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(organizationJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &updatedOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedOrganization: [%+v]", updatedOrganization)
+
+	return &updatedOrganization, nil
+}
+
+// DeleteOrganization deletes the ForemanOrganization identified by the
+// supplied ID
+func (c *Client) DeleteOrganization(id int) error {
+	log.Tracef("foreman/api/organization.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryOrganization queries for a ForemanOrganization based on the attributes
+// of the supplied ForemanOrganization reference and returns a QueryResponse
+// struct containing query/response metadata and the matching organizations.
+func (c *Client) QueryOrganization(o *ForemanOrganization) (QueryResponse, error) {
+	log.Tracef("foreman/api/organization.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", OrganizationEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + o.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanOrganization for
+	// the results
+	results := []ForemanOrganization{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanOrganization to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
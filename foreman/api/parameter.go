@@ -60,19 +60,14 @@ func (fp *ForemanParameter) UnmarshalJSON(b []byte) error {
 	}
 	fp.ForemanObject = fo
 
-	var fpMap map[string]interface{}
-	jsonDecErr = json.Unmarshal(b, &fpMap)
+	// The name, value and type of the parameter are decoded the same way as
+	// an inline ForemanKVParameter
+	var kv ForemanKVParameter
+	jsonDecErr = json.Unmarshal(b, &kv)
 	if jsonDecErr != nil {
 		return jsonDecErr
 	}
-
-	var ok bool
-	if fp.Parameter.Name, ok = fpMap["name"].(string); !ok {
-		fp.Parameter.Name = ""
-	}
-	if fp.Parameter.Value, ok = fpMap["value"].(string); !ok {
-		fp.Parameter.Value = ""
-	}
+	fp.Parameter = kv
 
 	return nil
 }
@@ -234,7 +229,7 @@ func (c *Client) QueryParameter(d *ForemanParameter) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	PermissionEndpointPrefix = "permissions"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPermission API model represents a permission to perform an action
+// on a resource type, ie: "view_hosts" on "Host".  Permissions are defined by
+// Foreman and its plugins and are granted through the filters of a role.
+type ForemanPermission struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Resource type the permission applies to
+	ResourceType string `json:"resource_type"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// ReadPermission reads the attributes of a ForemanPermission identified by the
+// supplied ID and returns a ForemanPermission reference.
+func (c *Client) ReadPermission(id int) (*ForemanPermission, error) {
+	log.Tracef("foreman/api/permission.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", PermissionEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readPermission ForemanPermission
+	sendErr := c.SendAndParse(req, &readPermission)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readPermission: [%+v]", readPermission)
+
+	return &readPermission, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryPermission queries for a ForemanPermission based on the attributes of
+// the supplied ForemanPermission reference and returns a QueryResponse struct
+// containing query/response metadata and the matching permissions.
+func (c *Client) QueryPermission(p *ForemanPermission) (QueryResponse, error) {
+	log.Tracef("foreman/api/permission.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", PermissionEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	search := []string{}
+	if p.Name != "" {
+		search = append(search, `name="`+p.Name+`"`)
+	}
+	if p.ResourceType != "" {
+		search = append(search, `resource_type="`+p.ResourceType+`"`)
+	}
+	reqQuery.Set("search", strings.Join(search, " and "))
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanPermission for
+	// the results
+	results := []ForemanPermission{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanPermission to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -330,7 +330,7 @@ func (c *Client) QueryProvisioningTemplate(t *ForemanProvisioningTemplate) (Quer
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	RealmEndpointPrefix = "realms"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRealm API model represents a realm.  A realm is an identity
+// management domain, ie: FreeIPA or Active Directory, hosts are joined to
+// during provisioning.  Foreman manages the hosts of a realm through a smart
+// proxy with the realm feature.
+type ForemanRealm struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Type of the realm, ie: "FreeIPA" or "Active Directory"
+	RealmType string
+	// ID of the smart proxy managing the realm
+	RealmProxyId int
+	// IDs of the locations and organizations the realm belongs to
+	LocationIds     []int
+	OrganizationIds []int
+}
+
+// ForemanRealm struct used for JSON decode.  Foreman API returns the taxonomies
+// of a realm as lists of ForemanObjects.
+type foremanRealmJSON struct {
+	RealmType     string          `json:"realm_type"`
+	RealmProxyId  int             `json:"realm_proxy_id"`
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+}
+
+// Implement the Marshaler interface
+func (fr ForemanRealm) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/realm.go#MarshalJSON")
+
+	frMap := map[string]interface{}{}
+
+	frMap["name"] = fr.Name
+	frMap["realm_type"] = fr.RealmType
+	frMap["realm_proxy_id"] = intIdToJSONString(fr.RealmProxyId)
+	frMap["location_ids"] = fr.LocationIds
+	frMap["organization_ids"] = fr.OrganizationIds
+
+	log.Debugf("frMap: [%v]", frMap)
+
+	return json.Marshal(frMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct and
+// then convert over to a ForemanRealm struct.
+func (fr *ForemanRealm) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/realm.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fr.ForemanObject = fo
+
+	// decode special JSON struct for keys that changed names
+	var frJSON foremanRealmJSON
+	jsonDecErr = json.Unmarshal(b, &frJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fr.RealmType = frJSON.RealmType
+	fr.RealmProxyId = frJSON.RealmProxyId
+	fr.LocationIds = foremanObjectArrayToIdIntArray(frJSON.Locations)
+	fr.OrganizationIds = foremanObjectArrayToIdIntArray(frJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRealm creates a new ForemanRealm with the attributes of the supplied
+// ForemanRealm reference and returns the created ForemanRealm reference. The
+// returned reference will have its ID and other API default values set by this
+// function.
+func (c *Client) CreateRealm(r *ForemanRealm) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RealmEndpointPrefix)
+
+	realmJSONBytes, jsonEncErr := WrapJson("realm", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("realmJSONBytes: [%s]", realmJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(realmJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &createdRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRealm: [%+v]", createdRealm)
+
+	return &createdRealm, nil
+}
+
+// ReadRealm reads the attributes of a ForemanRealm identified by the supplied
+// ID and returns a ForemanRealm reference.
+func (c *Client) ReadRealm(id int) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &readRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRealm: [%+v]", readRealm)
+
+	return &readRealm, nil
+}
+
+// UpdateRealm updates a ForemanRealm's attributes.  The realm with the ID of the
+// supplied ForemanRealm will be updated. A new ForemanRealm reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateRealm(r *ForemanRealm, id int) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, id)
+
+	realmJSONBytes, jsonEncErr := WrapJson("realm", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("realmJSONBytes: [%s]", realmJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(realmJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &updatedRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRealm: [%+v]", updatedRealm)
+
+	return &updatedRealm, nil
+}
+
+// DeleteRealm deletes the ForemanRealm identified by the supplied ID
+func (c *Client) DeleteRealm(id int) error {
+	log.Tracef("foreman/api/realm.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRealm queries for a ForemanRealm based on the attributes of the supplied
+// ForemanRealm reference and returns a QueryResponse struct containing
+// query/response metadata and the matching realms.
+func (c *Client) QueryRealm(r *ForemanRealm) (QueryResponse, error) {
+	log.Tracef("foreman/api/realm.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", RealmEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + r.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanRealm for
+	// the results
+	results := []ForemanRealm{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanRealm to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
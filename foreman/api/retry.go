@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Client Retry Policy
+// ----------------------------------------------------------------------------
+
+// shouldRetry determines whether a request should be sent again based on the
+// outcome of the previous attempt.
+//
+// Idempotent requests (GET, HEAD, PUT and DELETE) are retried if the server
+// could not be reached or if the server responded with a status code
+// indicating a temporary failure (429 Too Many Requests, 502 Bad Gateway, 503
+// Service Unavailable or 504 Gateway Timeout).
+//
+// Any other request, and power or boot actions which are sent as a PUT, may
+// have been carried out by the server even though the attempt failed.  They
+// are only retried if the server responded with 429 Too Many Requests or 503
+// Service Unavailable, which guarantee the request was not processed.
+//
+// Requests which were cancelled by the caller are never retried.
+func shouldRetry(req *http.Request, statusCode int, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if !isIdempotentRequest(req) {
+		return err == nil &&
+			(statusCode == http.StatusTooManyRequests ||
+				statusCode == http.StatusServiceUnavailable)
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) &&
+			!errors.Is(err, context.DeadlineExceeded)
+	}
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotentRequest returns whether sending the request more than once has
+// the same effect as sending it once.  Power and boot actions are not
+// idempotent although they are sent as a PUT, ie: a power cycle.  The power
+// state query is the exception, it only reads the state.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPut:
+		if strings.HasSuffix(req.URL.Path, "/"+PowerSuffix) {
+			return isPowerStateQuery(req)
+		}
+		return !strings.HasSuffix(req.URL.Path, "/"+BootSuffix)
+	}
+	return false
+}
+
+// isPowerStateQuery returns whether the body of a power request asks for the
+// power state of the host rather than performing a power action.
+func isPowerStateQuery(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+	body, bodyErr := req.GetBody()
+	if bodyErr != nil {
+		return false
+	}
+	defer body.Close()
+
+	var power Power
+	if jsonDecErr := json.NewDecoder(body).Decode(&power); jsonDecErr != nil {
+		return false
+	}
+	return power.PowerAction == PowerState
+}
+
+// retryWait returns how long to wait before the next attempt of a request.
+// If the server sent a valid Retry-After header, its value is honored up to
+// the client's maximum wait time.  Otherwise the wait time grows exponentially from the client's minimum wait
+// time with each attempt, is capped at the client's maximum wait time and
+// randomized between half and the full wait time (bounded by the minimum) so
+// concurrent requests do not retry in lockstep.  The attempt is the zero
+// based number of the attempt that failed and retryAfter the value of its
+// response's Retry-After header.
+func (client *Client) retryWait(attempt int, retryAfter string) time.Duration {
+	if wait, ok := parseRetryAfter(retryAfter); ok {
+		if wait > client.retryWaitMax {
+			wait = client.retryWaitMax
+		}
+		return wait
+	}
+
+	wait := client.retryWaitMin
+	for i := 0; i < attempt && wait < client.retryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > client.retryWaitMax {
+		wait = client.retryWaitMax
+	}
+
+	// jitter - wait somewhere between half and the full wait time, but never
+	// less than the minimum wait time
+	half := int64(wait / 2)
+	if half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+	if wait < client.retryWaitMin {
+		wait = client.retryWaitMin
+	}
+	return wait
+}
+
+// parseRetryAfter parses the value of a Retry-After header.  The header is
+// either a number of seconds or an HTTP date.  The second return value is
+// false if the header is empty or cannot be parsed.
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Client.Send retry policy
+// ----------------------------------------------------------------------------
+
+// Ensure Send() retries requests answered with a temporary failure and
+// replays the request body for every attempt
+func TestSend_RetryTemporaryFailure(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// dummy '[POST] /foo' endpoint - fails twice before succeeding
+	expectedBody := `{"foo":"bar"}`
+	attempts := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != expectedBody {
+			t.Errorf(
+				"Request body was not replayed on attempt [%d]. Expected [%s], "+
+					"got [%s]",
+				attempts,
+				expectedBody,
+				body,
+			)
+		}
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "/foo", bytes.NewBufferString(expectedBody))
+	statusCode, _, sendErr := client.Send(req)
+	if sendErr != nil || statusCode != http.StatusOK {
+		t.Fatalf(
+			"Client.Send() did not succeed after retrying. Expected [%d, nil], "+
+				"got [%d, %v]",
+			http.StatusOK,
+			statusCode,
+			sendErr,
+		)
+	}
+	if attempts != 3 {
+		t.Errorf(
+			"Client.Send() sent the wrong number of attempts. Expected [3], "+
+				"got [%d]",
+			attempts,
+		)
+	}
+}
+
+// Ensure Send() gives up after the configured number of retries and does not
+// retry status codes which do not indicate a temporary failure
+func TestSend_RetryLimits(t *testing.T) {
+	testCases := []struct {
+		statusCode       int
+		expectedAttempts int
+	}{
+		{http.StatusBadGateway, 3},
+		{http.StatusGatewayTimeout, 3},
+		{http.StatusInternalServerError, 1},
+		{http.StatusUnprocessableEntity, 1},
+		{http.StatusNotFound, 1},
+	}
+
+	cred := ClientCredentials{}
+	conf := ClientConfig{
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(cred, conf)
+
+		attempts := 0
+		statusCode := testCase.statusCode
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(statusCode)
+		})
+
+		req, _ := client.NewRequest(http.MethodGet, "/foo", nil)
+		client.Send(req)
+		server.Close()
+
+		if attempts != testCase.expectedAttempts {
+			t.Errorf(
+				"Client.Send() sent the wrong number of attempts for status code "+
+					"[%d]. Expected [%d], got [%d]",
+				testCase.statusCode,
+				testCase.expectedAttempts,
+				attempts,
+			)
+		}
+	}
+}
+
+// Ensure only idempotent requests, including the power state query, are
+// retried on any temporary failure, while other requests and power actions are
+// only retried if the server did not process them
+func TestShouldRetry(t *testing.T) {
+	testCases := []struct {
+		method     string
+		path       string
+		body       string
+		statusCode int
+		err        error
+		expected   bool
+	}{
+		{http.MethodGet, "/hosts/1", "", http.StatusBadGateway, nil, true},
+		{http.MethodGet, "/hosts/1", "", 0, errors.New("connection reset"), true},
+		{http.MethodGet, "/hosts/1", "", http.StatusInternalServerError, nil, false},
+		{http.MethodPut, "/hosts/1", "", http.StatusGatewayTimeout, nil, true},
+		{http.MethodDelete, "/hosts/1", "", http.StatusServiceUnavailable, nil, true},
+		{http.MethodPost, "/hosts", "", http.StatusTooManyRequests, nil, true},
+		{http.MethodPost, "/hosts", "", http.StatusServiceUnavailable, nil, true},
+		{http.MethodPost, "/hosts", "", http.StatusBadGateway, nil, false},
+		{http.MethodPost, "/hosts", "", http.StatusGatewayTimeout, nil, false},
+		{http.MethodPost, "/hosts", "", 0, errors.New("connection reset"), false},
+		{http.MethodPut, "/hosts/1/power", `{"power_action":"cycle"}`, http.StatusServiceUnavailable, nil, true},
+		{http.MethodPut, "/hosts/1/power", `{"power_action":"cycle"}`, http.StatusGatewayTimeout, nil, false},
+		{http.MethodPut, "/hosts/1/power", `{"power_action":"on"}`, 0, errors.New("connection reset"), false},
+		{http.MethodPut, "/hosts/1/power", `{"power_action":"state"}`, http.StatusBadGateway, nil, true},
+		{http.MethodPut, "/hosts/1/power", `{"power_action":"state"}`, http.StatusGatewayTimeout, nil, true},
+		{http.MethodPut, "/hosts/1/boot", "", http.StatusBadGateway, nil, false},
+	}
+
+	for _, testCase := range testCases {
+		req, _ := http.NewRequest(
+			testCase.method,
+			"https://foreman"+testCase.path,
+			bytes.NewBufferString(testCase.body),
+		)
+		retry := shouldRetry(req, testCase.statusCode, testCase.err)
+		if retry != testCase.expected {
+			t.Errorf(
+				"shouldRetry() returned the wrong result for [%s %s] with status "+
+					"code [%d] and error [%v]. Expected [%t], got [%t]",
+				testCase.method,
+				testCase.path,
+				testCase.statusCode,
+				testCase.err,
+				testCase.expected,
+				retry,
+			)
+		}
+	}
+}
+
+// Ensure the wait time between retries honors Retry-After up to the maximum
+// wait time and otherwise stays within the configured bounds
+func TestRetryWait(t *testing.T) {
+	client := NewClient(Server{}, ClientCredentials{}, ClientConfig{
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 10 * time.Second,
+	})
+
+	if wait := client.retryWait(0, "7"); wait != 7*time.Second {
+		t.Errorf(
+			"retryWait() did not honor the Retry-After header. Expected [%s], "+
+				"got [%s]",
+			7*time.Second,
+			wait,
+		)
+	}
+
+	// NOTE(ALL): an oversized Retry-After must not stall the client for
+	//   longer than the maximum wait time
+	date := time.Now().Add(3 * time.Hour).UTC().Format(http.TimeFormat)
+	for _, retryAfter := range []string{"3600", date} {
+		if wait := client.retryWait(0, retryAfter); wait != 10*time.Second {
+			t.Errorf(
+				"retryWait() did not clamp the Retry-After header [%s]. "+
+					"Expected [%s], got [%s]",
+				retryAfter,
+				10*time.Second,
+				wait,
+			)
+		}
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := client.retryWait(attempt, "")
+		if wait < time.Second || wait > 10*time.Second {
+			t.Errorf(
+				"retryWait() for attempt [%d] is out of bounds. Expected "+
+					"[1s - 10s], got [%s]",
+				attempt,
+				wait,
+			)
+		}
+	}
+}
+
+// Ensure both forms of the Retry-After header are parsed
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("120"); !ok || wait != 120*time.Second {
+		t.Errorf(
+			"parseRetryAfter() did not parse seconds. Expected [2m0s, true], "+
+				"got [%s, %t]",
+			wait,
+			ok,
+		)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > time.Hour {
+		t.Errorf(
+			"parseRetryAfter() did not parse an HTTP date. Expected [(0, 1h], "+
+				"true], got [%s, %t]",
+			wait,
+			ok,
+		)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("parseRetryAfter() accepted an invalid value")
+	}
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	RoleEndpointPrefix = "roles"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRole API model represents a role.  A role is a named collection of
+// filters, each granting a set of permissions, which is assigned to users and
+// user groups.
+type ForemanRole struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Description of the role
+	Description string `json:"description"`
+	// IDs of the locations and organizations the role is limited to
+	LocationIds     []int `json:"location_ids"`
+	OrganizationIds []int `json:"organization_ids"`
+}
+
+// ForemanRole struct used for JSON decode.  Foreman API returns the taxonomies
+// back as lists of ForemanObjects.  However, we are only interested in the IDs
+// returned.
+type foremanRoleJSON struct {
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct and
+// then convert over to a ForemanRole struct.
+func (fr *ForemanRole) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/role.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fr.ForemanObject = fo
+
+	// decode the remaining properties through a map
+	var frMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &frMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var ok bool
+	if fr.Description, ok = frMap["description"].(string); !ok {
+		fr.Description = ""
+	}
+
+	// decode special JSON struct for keys that changed names
+	var frJSON foremanRoleJSON
+	jsonDecErr = json.Unmarshal(b, &frJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fr.LocationIds = foremanObjectArrayToIdIntArray(frJSON.Locations)
+	fr.OrganizationIds = foremanObjectArrayToIdIntArray(frJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRole creates a new ForemanRole with the attributes of the supplied
+// ForemanRole reference and returns the created ForemanRole reference.  The
+// returned reference will have its ID and other API default values set by this
+// function.
+func (c *Client) CreateRole(r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+
+	roleJSONBytes, jsonEncErr := WrapJson("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRole ForemanRole
+	sendErr := c.SendAndParse(req, &createdRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRole: [%+v]", createdRole)
+
+	return &createdRole, nil
+}
+
+// ReadRole reads the attributes of a ForemanRole identified by the supplied ID
+// and returns a ForemanRole reference.
+func (c *Client) ReadRole(id int) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRole ForemanRole
+	sendErr := c.SendAndParse(req, &readRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRole: [%+v]", readRole)
+
+	return &readRole, nil
+}
+
+// UpdateRole updates a ForemanRole's attributes.  The role with the ID of the
+// supplied ForemanRole will be updated. A new ForemanRole reference is returned
+// with the attributes from the result of the update operation.
+func (c *Client) UpdateRole(r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, r.Id)
+
+	roleJSONBytes, jsonEncErr := WrapJson("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRole ForemanRole
+	sendErr := c.SendAndParse(req, &updatedRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRole: [%+v]", updatedRole)
+
+	return &updatedRole, nil
+}
+
+// DeleteRole deletes the ForemanRole identified by the supplied ID
+func (c *Client) DeleteRole(id int) error {
+	log.Tracef("foreman/api/role.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRole queries for a ForemanRole based on the attributes of the supplied
+// ForemanRole reference and returns a QueryResponse struct containing
+// query/response metadata and the matching roles.
+func (c *Client) QueryRole(r *ForemanRole) (QueryResponse, error) {
+	log.Tracef("foreman/api/role.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + r.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanRole for
+	// the results
+	results := []ForemanRole{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanRole to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
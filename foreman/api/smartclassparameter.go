@@ -25,9 +25,9 @@ type ForemanSmartClassParameter struct {
 
 	Match                 string `json:"match"`
 	Value                 string `json:"value"`
-	UsePuppetDefault      bool   `json:use_puppet_default`
-	Omit                  bool   `json:omit`
-	SmartClassParameterId string `json:smart_class_parameter_id`
+	UsePuppetDefault      bool   `json:"use_puppet_default"`
+	Omit                  bool   `json:"omit"`
+	SmartClassParameterId string `json:"smart_class_parameter_id"`
 }
 
 // Implement the Marshaler interface
@@ -240,7 +240,7 @@ func (c *Client) QuerySmartClassParameter(d *ForemanSmartClassParameter) (QueryR
 	reqQuery.Set("search", "match="+match)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
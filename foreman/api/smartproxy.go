@@ -33,6 +33,33 @@ type ForemanSmartProxy struct {
 
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
+
+	// NOTE(ALL): Features are discovered by Foreman when the proxy is
+	//   registered or refreshed.  They are never set by the provider and
+	//   therefore omitted from the JSON marshal.
+	// Features advertised by the proxy
+	Features []ForemanSmartProxyFeature `json:"features,omitempty"`
+}
+
+// The ForemanSmartProxyFeature API model represents a feature advertised by
+// a smart proxy (ie: DHCP, DNS, TFTP) along with the feature's capabilities.
+type ForemanSmartProxyFeature struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Capabilities of the feature (ie: "dhcp_filename_ipv4" for DHCP)
+	Capabilities []string `json:"capabilities"`
+}
+
+// HasFeature returns whether or not the smart proxy advertises the feature
+// with the supplied name.
+func (fs *ForemanSmartProxy) HasFeature(name string) bool {
+	for _, feature := range fs.Features {
+		if feature.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------------------------------------
@@ -156,6 +183,26 @@ func (c *Client) DeleteSmartProxy(id int) error {
 	return c.SendAndParse(req, nil)
 }
 
+// RefreshSmartProxyFeatures makes Foreman rediscover the features advertised
+// by the ForemanSmartProxy identified by the supplied ID.  Read the smart
+// proxy afterwards to get the refreshed features.
+func (c *Client) RefreshSmartProxyFeatures(id int) error {
+	log.Tracef("foreman/api/smartproxy.go#RefreshFeatures")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/refresh", SmartProxyEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
 // -----------------------------------------------------------------------------
 // Query Implementation
 // -----------------------------------------------------------------------------
@@ -184,7 +231,7 @@ func (c *Client) QuerySmartProxy(s *ForemanSmartProxy) (QueryResponse, error) {
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -43,8 +43,188 @@ type ForemanSubnet struct {
 	// Default boot mode for instances assigned to this subnet.  If set, valid
 	// values are "Static" and "DHCP".
 	BootMode string `json:"boot_mode"`
+	// Free-form description of the subnet
+	Description string `json:"description"`
+	// VLAN ID for this subnet
+	Vlanid int `json:"vlanid"`
+	// MTU for this subnet
+	Mtu int `json:"mtu"`
+
+	// ID of the smart proxy providing DHCP for this subnet
+	DhcpId int `json:"dhcp_id"`
+	// ID of the smart proxy providing TFTP for this subnet
+	TftpId int `json:"tftp_id"`
+	// ID of the smart proxy providing reverse DNS for this subnet
+	DnsId int `json:"dns_id"`
+	// ID of the smart proxy providing templates for this subnet
+	TemplateId int `json:"template_id"`
+	// ID of the smart proxy providing BMC for this subnet
+	BmcId int `json:"bmc_id"`
+	// ID of the smart proxy providing external IPAM for this subnet
+	ExternalIpamId int `json:"externalipam_id"`
+	// IDs of the smart proxies used for remote execution on this subnet
+	RemoteExecutionProxyIds []int `json:"remote_execution_proxy_ids"`
 
 	Domains []int `json:"domain_ids"`
+	// ID of the organization the subnet belongs to
+	OrganizationId int `json:"organization_id"`
+}
+
+// ForemanSubnet struct used for JSON decode.  Foreman API returns the
+// domains and organizations of a subnet as a list of ForemanObjects.  Older
+// Foreman versions only return the associated smart proxies as nested
+// objects rather than as "<proxy>_id" keys.
+type foremanSubnetJSON struct {
+	Domains                []ForemanObject `json:"domains"`
+	Organizations          []ForemanObject `json:"organizations"`
+	RemoteExecutionProxies []ForemanObject `json:"remote_execution_proxies"`
+
+	Dhcp         *ForemanObject `json:"dhcp"`
+	Tftp         *ForemanObject `json:"tftp"`
+	Dns          *ForemanObject `json:"dns"`
+	Template     *ForemanObject `json:"template"`
+	Bmc          *ForemanObject `json:"bmc"`
+	ExternalIpam *ForemanObject `json:"externalipam"`
+}
+
+// subnetProxyId returns the ID of a subnet's smart proxy association.  The
+// "<proxy>_id" key of the response takes precedence over the nested object.
+func subnetProxyId(mapValue interface{}, obj *ForemanObject) int {
+	if id := unmarshalInteger(mapValue); id > 0 {
+		return id
+	}
+	if obj != nil {
+		return obj.Id
+	}
+	return 0
+}
+
+// Implement the Marshaler interface
+func (fs ForemanSubnet) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/subnet.go#MarshalJSON")
+
+	fsMap := map[string]interface{}{}
+
+	fsMap["name"] = fs.Name
+	fsMap["network"] = fs.Network
+	fsMap["mask"] = fs.Mask
+	fsMap["gateway"] = fs.Gateway
+	fsMap["dns_primary"] = fs.DnsPrimary
+	fsMap["dns_secondary"] = fs.DnsSecondary
+	fsMap["ipam"] = fs.Ipam
+	fsMap["from"] = fs.From
+	fsMap["to"] = fs.To
+	fsMap["boot_mode"] = fs.BootMode
+	fsMap["description"] = fs.Description
+	fsMap["domain_ids"] = fs.Domains
+
+	// NOTE(ALL): 0 is not a valid VLAN ID or MTU.  Send null for the VLAN ID
+	//   and leave out the MTU so Foreman falls back to its default.
+	fsMap["vlanid"] = intIdToJSONString(fs.Vlanid)
+	if fs.Mtu > 0 {
+		fsMap["mtu"] = fs.Mtu
+	}
+
+	fsMap["dhcp_id"] = intIdToJSONString(fs.DhcpId)
+	fsMap["tftp_id"] = intIdToJSONString(fs.TftpId)
+	fsMap["dns_id"] = intIdToJSONString(fs.DnsId)
+	fsMap["template_id"] = intIdToJSONString(fs.TemplateId)
+	fsMap["bmc_id"] = intIdToJSONString(fs.BmcId)
+	fsMap["externalipam_id"] = intIdToJSONString(fs.ExternalIpamId)
+
+	// NOTE(ALL): remote_execution_proxy_ids is provided by the remote
+	//   execution plugin.  A nil list was not configured and is left out so
+	//   subnets on Foreman instances without the plugin are left untouched.
+	//   An empty list removes the proxies.
+	if fs.RemoteExecutionProxyIds != nil {
+		fsMap["remote_execution_proxy_ids"] = fs.RemoteExecutionProxyIds
+	}
+
+	setOrganizationIds(fsMap, fs.OrganizationId)
+
+	log.Debugf("fsMap: [%v]", fsMap)
+
+	return json.Marshal(fsMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanSubnet struct.
+func (fs *ForemanSubnet) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/subnet.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fs.ForemanObject = fo
+
+	// Unmarshal to temporary JSON struct to get the properties with differently
+	// named keys
+	var fsJSON foremanSubnetJSON
+	jsonDecErr = json.Unmarshal(b, &fsJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fs.Domains = foremanObjectArrayToIdIntArray(fsJSON.Domains)
+	if orgIds := foremanObjectArrayToIdIntArray(fsJSON.Organizations); len(orgIds) > 0 {
+		fs.OrganizationId = orgIds[0]
+	}
+	fs.RemoteExecutionProxyIds = foremanObjectArrayToIdIntArray(fsJSON.RemoteExecutionProxies)
+
+	// Unmarshal into mapstructure and set the rest of the struct properties
+	var fsMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &fsMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var ok bool
+	if fs.Network, ok = fsMap["network"].(string); !ok {
+		fs.Network = ""
+	}
+	if fs.Mask, ok = fsMap["mask"].(string); !ok {
+		fs.Mask = ""
+	}
+	if fs.Gateway, ok = fsMap["gateway"].(string); !ok {
+		fs.Gateway = ""
+	}
+	if fs.DnsPrimary, ok = fsMap["dns_primary"].(string); !ok {
+		fs.DnsPrimary = ""
+	}
+	if fs.DnsSecondary, ok = fsMap["dns_secondary"].(string); !ok {
+		fs.DnsSecondary = ""
+	}
+	if fs.Ipam, ok = fsMap["ipam"].(string); !ok {
+		fs.Ipam = ""
+	}
+	if fs.From, ok = fsMap["from"].(string); !ok {
+		fs.From = ""
+	}
+	if fs.To, ok = fsMap["to"].(string); !ok {
+		fs.To = ""
+	}
+	if fs.BootMode, ok = fsMap["boot_mode"].(string); !ok {
+		fs.BootMode = ""
+	}
+	if fs.Description, ok = fsMap["description"].(string); !ok {
+		fs.Description = ""
+	}
+
+	// NOTE(ALL): Foreman returns an empty string for an unset VLAN ID
+	fs.Vlanid = unmarshalInteger(fsMap["vlanid"])
+	fs.Mtu = unmarshalInteger(fsMap["mtu"])
+
+	fs.DhcpId = subnetProxyId(fsMap["dhcp_id"], fsJSON.Dhcp)
+	fs.TftpId = subnetProxyId(fsMap["tftp_id"], fsJSON.Tftp)
+	fs.DnsId = subnetProxyId(fsMap["dns_id"], fsJSON.Dns)
+	fs.TemplateId = subnetProxyId(fsMap["template_id"], fsJSON.Template)
+	fs.BmcId = subnetProxyId(fsMap["bmc_id"], fsJSON.Bmc)
+	fs.ExternalIpamId = subnetProxyId(fsMap["externalipam_id"], fsJSON.ExternalIpam)
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -200,7 +380,7 @@ func (c *Client) QuerySubnet(s *ForemanSubnet) (QueryResponse, error) {
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -230,3 +410,84 @@ func (c *Client) QuerySubnet(s *ForemanSubnet) (QueryResponse, error) {
 
 	return queryResponse, nil
 }
+
+// SearchSubnets queries for the ForemanSubnets matching the supplied Foreman scoped
+// search expression, ordered by the supplied order expression, and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// subnets.
+func (c *Client) SearchSubnets(search string, order string) (QueryResponse, error) {
+	log.Tracef("foreman/api/subnet.go#SearchSubnets")
+
+	results := []ForemanSubnet{}
+	queryResponse, searchErr := c.search(SubnetEndpointPrefix, search, order, &results)
+	if searchErr != nil {
+		return queryResponse, searchErr
+	}
+
+	// convert the search results from []ForemanSubnet to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
+// -----------------------------------------------------------------------------
+// Free IP Suggestion
+// -----------------------------------------------------------------------------
+
+// foremanSubnetFreeIpJSON is the response of a subnet's freeip endpoint.
+// Foreman 1.18 and later return the address as "freeip", older versions
+// return it as "ip".
+type foremanSubnetFreeIpJSON struct {
+	FreeIp string `json:"freeip"`
+	Ip     string `json:"ip"`
+}
+
+// SuggestSubnetFreeIp asks Foreman for the next free IP address of the
+// subnet identified by the supplied ID.  The suggestion honours the IPAM mode
+// and the from/to range of the subnet.  The MAC address is optional and only
+// used by the EUI-64 IPAM mode.  Addresses in excludedIps are never returned.
+func (c *Client) SuggestSubnetFreeIp(id int, mac string, excludedIps []string) (string, error) {
+	log.Tracef("foreman/api/subnet.go#SuggestSubnetFreeIp")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/freeip", SubnetEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	if mac != "" {
+		reqQuery.Set("mac", mac)
+	}
+	for _, ip := range excludedIps {
+		reqQuery.Add("excluded_ips[]", ip)
+	}
+	req.URL.RawQuery = reqQuery.Encode()
+
+	var freeIpJSON foremanSubnetFreeIpJSON
+	sendErr := c.SendAndParse(req, &freeIpJSON)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	log.Debugf("freeIpJSON: [%+v]", freeIpJSON)
+
+	if freeIpJSON.FreeIp != "" {
+		return freeIpJSON.FreeIp, nil
+	}
+	if freeIpJSON.Ip != "" {
+		return freeIpJSON.Ip, nil
+	}
+
+	return "", fmt.Errorf("Foreman did not suggest a free IP address for subnet [%d]", id)
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	UserEndpointPrefix = "users"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanUser API model represents a user account.  A user authenticates
+// against an authentication source (internal or external, ie: LDAP), is a
+// member of locations and organizations and is granted permissions through
+// the roles assigned to the user.
+type ForemanUser struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Login name of the user
+	Login string
+	// First and last name of the user
+	Firstname string
+	Lastname  string
+	// E-mail address of the user
+	Mail string
+	// Description of the user
+	Description string
+	// Whether or not the user is an administrator
+	Admin bool
+	// Password of the user.  Only sent to Foreman, never returned by the API.
+	Password string
+	// ID of the authentication source the user authenticates against
+	AuthSourceId int
+	// IDs of the location and organization the user logs into by default
+	DefaultLocationId     int
+	DefaultOrganizationId int
+	// IDs of the locations and organizations the user is a member of
+	LocationIds     []int
+	OrganizationIds []int
+	// IDs of the roles assigned to the user
+	RoleIds []int
+}
+
+// ForemanUser struct used for JSON decode.  Foreman API returns the
+// associated objects as ForemanObjects.  However, we are only interested in
+// the IDs returned.
+type foremanUserJSON struct {
+	Login               string          `json:"login"`
+	Firstname           string          `json:"firstname"`
+	Lastname            string          `json:"lastname"`
+	Mail                string          `json:"mail"`
+	Description         string          `json:"description"`
+	Admin               bool            `json:"admin"`
+	AuthSourceId        int             `json:"auth_source_id"`
+	DefaultLocation     *ForemanObject  `json:"default_location"`
+	DefaultOrganization *ForemanObject  `json:"default_organization"`
+	Locations           []ForemanObject `json:"locations"`
+	Organizations       []ForemanObject `json:"organizations"`
+	Roles               []ForemanObject `json:"roles"`
+}
+
+// Implement the Marshaler interface
+func (fu ForemanUser) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/user.go#MarshalJSON")
+
+	fuMap := map[string]interface{}{}
+
+	fuMap["login"] = fu.Login
+	fuMap["firstname"] = fu.Firstname
+	fuMap["lastname"] = fu.Lastname
+	fuMap["mail"] = fu.Mail
+	fuMap["description"] = fu.Description
+	fuMap["admin"] = fu.Admin
+	fuMap["auth_source_id"] = intIdToJSONString(fu.AuthSourceId)
+	fuMap["default_location_id"] = intIdToJSONString(fu.DefaultLocationId)
+	fuMap["default_organization_id"] = intIdToJSONString(fu.DefaultOrganizationId)
+	fuMap["location_ids"] = fu.LocationIds
+	fuMap["organization_ids"] = fu.OrganizationIds
+	fuMap["role_ids"] = fu.RoleIds
+
+	// NOTE(ALL): only send the password if it is set, an empty password
+	//   would otherwise fail validation or reset the user's password
+	if fu.Password != "" {
+		fuMap["password"] = fu.Password
+	}
+
+	return json.Marshal(fuMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct
+// and then convert over to a ForemanUser struct.
+func (fu *ForemanUser) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/user.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fu.ForemanObject = obj
+
+	// decode special JSON struct for keys that changed names
+	var fuJSON foremanUserJSON
+	jsonDecErr = json.Unmarshal(b, &fuJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fu.Login = fuJSON.Login
+	fu.Firstname = fuJSON.Firstname
+	fu.Lastname = fuJSON.Lastname
+	fu.Mail = fuJSON.Mail
+	fu.Description = fuJSON.Description
+	fu.Admin = fuJSON.Admin
+	fu.AuthSourceId = fuJSON.AuthSourceId
+	if fuJSON.DefaultLocation != nil {
+		fu.DefaultLocationId = fuJSON.DefaultLocation.Id
+	}
+	if fuJSON.DefaultOrganization != nil {
+		fu.DefaultOrganizationId = fuJSON.DefaultOrganization.Id
+	}
+	fu.LocationIds = foremanObjectArrayToIdIntArray(fuJSON.Locations)
+	fu.OrganizationIds = foremanObjectArrayToIdIntArray(fuJSON.Organizations)
+	fu.RoleIds = foremanObjectArrayToIdIntArray(fuJSON.Roles)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateUser creates a new ForemanUser with the attributes of the supplied
+// ForemanUser reference and returns the created ForemanUser reference.  The
+// returned reference will have its ID and other API default values set by
+// this function.
+func (c *Client) CreateUser(u *ForemanUser) (*ForemanUser, error) {
+	log.Tracef("foreman/api/user.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", UserEndpointPrefix)
+
+	userJSONBytes, jsonEncErr := WrapJson("user", u)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request data contains the user's password, do not log it
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(userJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdUser ForemanUser
+	sendErr := c.SendAndParse(req, &createdUser)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdUser: [%+v]", createdUser)
+
+	return &createdUser, nil
+}
+
+// ReadUser reads the attributes of a ForemanUser identified by the supplied
+// ID and returns a ForemanUser reference.
+func (c *Client) ReadUser(id int) (*ForemanUser, error) {
+	log.Tracef("foreman/api/user.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UserEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readUser ForemanUser
+	sendErr := c.SendAndParse(req, &readUser)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readUser: [%+v]", readUser)
+
+	return &readUser, nil
+}
+
+// UpdateUser updates a ForemanUser's attributes.  The user with the ID of the
+// supplied ForemanUser will be updated. A new ForemanUser reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateUser(u *ForemanUser) (*ForemanUser, error) {
+	log.Tracef("foreman/api/user.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UserEndpointPrefix, u.Id)
+
+	userJSONBytes, jsonEncErr := WrapJson("user", u)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request data contains the user's password, do not log it
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(userJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedUser ForemanUser
+	sendErr := c.SendAndParse(req, &updatedUser)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedUser: [%+v]", updatedUser)
+
+	return &updatedUser, nil
+}
+
+// DeleteUser deletes the ForemanUser identified by the supplied ID
+func (c *Client) DeleteUser(id int) error {
+	log.Tracef("foreman/api/user.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UserEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryUser queries for a ForemanUser based on the attributes of the supplied
+// ForemanUser reference and returns a QueryResponse struct containing
+// query/response metadata and the matching users.
+func (c *Client) QueryUser(u *ForemanUser) (QueryResponse, error) {
+	log.Tracef("foreman/api/user.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", UserEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	login := `"` + u.Login + `"`
+	reqQuery.Set("search", "login="+login)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanUser for the results
+	results := []ForemanUser{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanUser to []interface and set
+	// the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	UsergroupEndpointPrefix = "usergroups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanUsergroup API model represents a group of users.  Members of a
+// usergroup are users and other, nested usergroups.  The members inherit the
+// roles assigned to the usergroup.  External usergroups map groups of an
+// authentication source, ie: LDAP, to a usergroup.
+type ForemanUsergroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Whether or not the members of the usergroup are administrators
+	Admin bool
+	// IDs of the users and nested usergroups which are members of the
+	// usergroup
+	UserIds      []int
+	UsergroupIds []int
+	// IDs of the roles assigned to the usergroup
+	RoleIds []int
+}
+
+// ForemanUsergroup struct used for JSON decode.  Foreman API returns the
+// associated objects as ForemanObjects.  However, we are only interested in the
+// IDs returned.
+type foremanUsergroupJSON struct {
+	Admin      bool            `json:"admin"`
+	Users      []ForemanObject `json:"users"`
+	Usergroups []ForemanObject `json:"usergroups"`
+	Roles      []ForemanObject `json:"roles"`
+}
+
+// Implement the Marshaler interface
+func (fu ForemanUsergroup) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/usergroup.go#MarshalJSON")
+
+	fuMap := map[string]interface{}{}
+
+	fuMap["name"] = fu.Name
+	fuMap["admin"] = fu.Admin
+	fuMap["user_ids"] = fu.UserIds
+	fuMap["usergroup_ids"] = fu.UsergroupIds
+	fuMap["role_ids"] = fu.RoleIds
+
+	log.Debugf("fuMap: [%v]", fuMap)
+
+	return json.Marshal(fuMap)
+}
+
+// Custom JSON unmarshal function.  Unmarshal to the unexported JSON struct and
+// then convert over to a ForemanUsergroup struct.
+func (fu *ForemanUsergroup) UnmarshalJSON(b []byte) error {
+	log.Tracef("foreman/api/usergroup.go#UnmarshalJSON")
+
+	var jsonDecErr error
+
+	// decode base forman object
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fu.ForemanObject = obj
+
+	// decode special JSON struct for keys that changed names
+	var fuJSON foremanUsergroupJSON
+	jsonDecErr = json.Unmarshal(b, &fuJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fu.Admin = fuJSON.Admin
+	fu.UserIds = foremanObjectArrayToIdIntArray(fuJSON.Users)
+	fu.UsergroupIds = foremanObjectArrayToIdIntArray(fuJSON.Usergroups)
+	fu.RoleIds = foremanObjectArrayToIdIntArray(fuJSON.Roles)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateUsergroup creates a new ForemanUsergroup with the attributes of the
+// supplied ForemanUsergroup reference and returns the created ForemanUsergroup
+// reference.  The returned reference will have its ID and other API default
+// values set by this function.
+func (c *Client) CreateUsergroup(u *ForemanUsergroup) (*ForemanUsergroup, error) {
+	log.Tracef("foreman/api/usergroup.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", UsergroupEndpointPrefix)
+
+	usergroupJSONBytes, jsonEncErr := WrapJson("usergroup", u)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("usergroupJSONBytes: [%s]", usergroupJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(usergroupJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdUsergroup ForemanUsergroup
+	sendErr := c.SendAndParse(req, &createdUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdUsergroup: [%+v]", createdUsergroup)
+
+	return &createdUsergroup, nil
+}
+
+// ReadUsergroup reads the attributes of a ForemanUsergroup identified by the
+// supplied ID and returns a ForemanUsergroup reference.
+func (c *Client) ReadUsergroup(id int) (*ForemanUsergroup, error) {
+	log.Tracef("foreman/api/usergroup.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UsergroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readUsergroup ForemanUsergroup
+	sendErr := c.SendAndParse(req, &readUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readUsergroup: [%+v]", readUsergroup)
+
+	return &readUsergroup, nil
+}
+
+// UpdateUsergroup updates a ForemanUsergroup's attributes.  The usergroup with
+// the ID of the supplied ForemanUsergroup will be updated. A new
+// ForemanUsergroup reference is returned with the attributes from the result of
+// the update operation.
+func (c *Client) UpdateUsergroup(u *ForemanUsergroup) (*ForemanUsergroup, error) {
+	log.Tracef("foreman/api/usergroup.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UsergroupEndpointPrefix, u.Id)
+
+	usergroupJSONBytes, jsonEncErr := WrapJson("usergroup", u)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("usergroupJSONBytes: [%s]", usergroupJSONBytes)
+
+	req, reqErr := c.NewRequest(
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(usergroupJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedUsergroup ForemanUsergroup
+	sendErr := c.SendAndParse(req, &updatedUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedUsergroup: [%+v]", updatedUsergroup)
+
+	return &updatedUsergroup, nil
+}
+
+// DeleteUsergroup deletes the ForemanUsergroup identified by the supplied ID
+func (c *Client) DeleteUsergroup(id int) error {
+	log.Tracef("foreman/api/usergroup.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", UsergroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequest(
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryUsergroup queries for a ForemanUsergroup based on the attributes of the
+// supplied ForemanUsergroup reference and returns a QueryResponse struct
+// containing query/response metadata and the matching usergroups.
+func (c *Client) QueryUsergroup(u *ForemanUsergroup) (QueryResponse, error) {
+	log.Tracef("foreman/api/usergroup.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", UsergroupEndpointPrefix)
+	req, reqErr := c.NewRequest(
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + u.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanUsergroup for the results
+	results := []ForemanUsergroup{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanUsergroup to []interface and set
+	// the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
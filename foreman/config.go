@@ -1,6 +1,13 @@
 package foreman
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -17,8 +24,24 @@ type Config struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information.
 	ClientTLSInsecure bool
+	// CA bundle used to verify the server's certificate and the client
+	// certificate and key presented to the server for mutual TLS.  Each value
+	// is either PEM encoded content or the path to a PEM encoded file.
+	ClientTLSCACert     string
+	ClientTLSClientCert string
+	ClientTLSClientKey  string
+	// Name used to verify the server's certificate instead of the server's
+	// hostname
+	ClientTLSServerName string
 	// Set of credentials needed to authenticate against Foreman
 	ClientCredentials api.ClientCredentials
+	// Number of results to request per page when querying the API
+	ClientQueryPerPage int
+	// Number of times to retry a failed request and the minimum and maximum
+	// time to wait between retries
+	ClientMaxRetries   int
+	ClientRetryWaitMin time.Duration
+	ClientRetryWaitMax time.Duration
 }
 
 // Client creates a client reference for the Foreman REST API given the
@@ -28,11 +51,56 @@ type Config struct {
 func (c *Config) Client() (*api.Client, error) {
 	log.Tracef("config.go#Client")
 
+	var rootCAs *x509.CertPool
+	if c.ClientTLSCACert != "" {
+		caPEM, readErr := readPEM(c.ClientTLSCACert)
+		if readErr != nil {
+			return nil, fmt.Errorf("Failed to read the CA bundle: %s", readErr)
+		}
+		// NOTE(ALL): trust the system's CAs in addition to the bundle
+		if rootCAs, _ = x509.SystemCertPool(); rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("The CA bundle does not contain any PEM encoded certificates")
+		}
+	}
+
+	var clientCerts []tls.Certificate
+	if c.ClientTLSClientCert != "" || c.ClientTLSClientKey != "" {
+		if c.ClientTLSClientCert == "" || c.ClientTLSClientKey == "" {
+			return nil, fmt.Errorf(
+				"Both a client certificate and a client key are required for " +
+					"mutual TLS authentication",
+			)
+		}
+		certPEM, certErr := readPEM(c.ClientTLSClientCert)
+		if certErr != nil {
+			return nil, fmt.Errorf("Failed to read the client certificate: %s", certErr)
+		}
+		keyPEM, keyErr := readPEM(c.ClientTLSClientKey)
+		if keyErr != nil {
+			return nil, fmt.Errorf("Failed to read the client key: %s", keyErr)
+		}
+		clientCert, pairErr := tls.X509KeyPair(certPEM, keyPEM)
+		if pairErr != nil {
+			return nil, fmt.Errorf("Failed to load the client certificate: %s", pairErr)
+		}
+		clientCerts = append(clientCerts, clientCert)
+	}
+
 	client := api.NewClient(
 		c.Server,
 		c.ClientCredentials,
 		api.ClientConfig{
-			TLSInsecureEnabled: c.ClientTLSInsecure,
+			TLSInsecureEnabled:    c.ClientTLSInsecure,
+			TLSRootCAs:            rootCAs,
+			TLSClientCertificates: clientCerts,
+			TLSServerName:         c.ClientTLSServerName,
+			QueryPerPage:          c.ClientQueryPerPage,
+			MaxRetries:            c.ClientMaxRetries,
+			RetryWaitMin:          c.ClientRetryWaitMin,
+			RetryWaitMax:          c.ClientRetryWaitMax,
 		},
 	)
 
@@ -40,3 +108,12 @@ func (c *Config) Client() (*api.Client, error) {
 
 	return client, nil
 }
+
+// readPEM returns the PEM encoded content of a TLS setting.  The setting is
+// either the PEM encoded content itself or the path to a file containing it.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
@@ -21,7 +21,8 @@ func dataSourceForemanComputeProfile() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				Description: fmt.Sprintf(
-					"Compute Profile",
+					"The name of the compute profile. "+
+						"%s \"1-Small\"",
 					autodoc.MetaExample,
 				),
 			},
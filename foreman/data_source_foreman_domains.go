@@ -0,0 +1,91 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanDomains() *schema.Resource {
+	ds := searchDataSourceSchema(
+		"Domains matching a Foreman scoped search expression.",
+	)
+
+	ds["domains"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the domain.",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the domain.",
+				},
+				"fullname": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the domain.",
+				},
+			},
+		},
+		Description: "Domains matching the search, in the order returned by " +
+			"Foreman.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanDomainsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanDomainsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_domains.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+	order := d.Get("order").(string)
+
+	log.Debugf("search: [%s], order: [%s]", search, order)
+
+	queryResponse, queryErr := client.SearchDomains(search, order)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	domains := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		dom, ok := result.(api.ForemanDomain)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanDomain], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, dom.Id)
+		domains = append(domains, map[string]interface{}{
+			"id":       dom.Id,
+			"name":     dom.Name,
+			"fullname": dom.Fullname,
+		})
+	}
+
+	log.Debugf("ids: [%v]", ids)
+
+	d.SetId(searchDataSourceId(search, order))
+	d.Set("ids", ids)
+	d.Set("domains", domains)
+
+	return nil
+}
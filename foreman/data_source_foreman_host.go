@@ -0,0 +1,196 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanHost() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanHost()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// NOTE(ALL): These attributes only control how the resource provisions a
+	//   host and have no meaning for a host which is looked up
+	delete(ds, "enable_bmc")
+	delete(ds, "bmc_success")
+	delete(ds, "wait_for_build")
+	delete(ds, "rebuild_trigger")
+	delete(ds, "rebuild_power_cycle")
+	delete(ds, "retry_count")
+	delete(ds, "power_state")
+	delete(ds, "parameter")
+
+	ds[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Looks up a host by its FQDN, ID or a search expression. The "+
+				"host does not need to be managed by terraform, ie: it was "+
+				"discovered or built in the Foreman UI.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	// define searchable attributes for the data source
+
+	ds["fqdn"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"fqdn", "host_id", "search"},
+		Description: fmt.Sprintf(
+			"Fully qualified domain name of the host. "+
+				"%s \"host01.dev.dc1.company.com\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["host_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"fqdn", "host_id", "search"},
+		Description:  "ID of the host.",
+	}
+	ds["search"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{"fqdn", "host_id", "search"},
+		Description: fmt.Sprintf(
+			"A Foreman scoped search expression. Exactly one host must match. "+
+				"%s \"mac = c0:ff:ee:ba:be:00\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	// attributes reported by Foreman which the resource does not manage
+
+	ds["hostgroup_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the host's hostgroup.",
+	}
+	ds["operatingsystem_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the host's operating system.",
+	}
+	ds["environment_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the host's environment.",
+	}
+	ds["compute_resource_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the compute resource the host is deployed on.",
+	}
+	ds["compute_profile_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the host's compute profile.",
+	}
+	ds["uuid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UUID of the host's virtual machine on the compute resource.",
+	}
+	ds["build_status"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+		Description: "Build status of the host. 0 = built, 1 = pending " +
+			"installation, 2 = token expired, 3 = build failed.",
+	}
+	ds["build_status_label"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Human readable form of the build status.",
+	}
+	ds["installed_at"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the host finished its installation.",
+	}
+	ds["last_report"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time of the last report Foreman received from the host.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// setDataSourceFromForemanHost sets the attributes of a host data source from
+// the attributes of the supplied ForemanHost reference.  The attributes shared
+// with the resource are set by setResourceDataFromForemanHost().
+func setDataSourceFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost) {
+	log.Tracef("data_source_foreman_host.go#setDataSourceFromForemanHost")
+
+	setResourceDataFromForemanHost(d, fh)
+
+	d.Set("host_id", fh.Id)
+	d.Set("method", fh.Method)
+	d.Set("hostgroup_name", fh.HostgroupName)
+	d.Set("operatingsystem_name", fh.OperatingSystemName)
+	d.Set("environment_name", fh.EnvironmentName)
+	d.Set("compute_resource_name", fh.ComputeResourceName)
+	d.Set("compute_profile_name", fh.ComputeProfileName)
+	d.Set("uuid", fh.Uuid)
+	d.Set("build_status", fh.BuildStatus)
+	d.Set("build_status_label", fh.BuildStatusLabel)
+	d.Set("installed_at", fh.InstalledAt)
+	d.Set("last_report", fh.LastReport)
+}
+
+// -----------------------------------------------------------------------------
+// Data Source CRUD Operations
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanHostRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_host.go#Read")
+
+	client := meta.(*api.Client)
+
+	// NOTE(ALL): The search results do not contain the interfaces and
+	//   parameters of a host.  A host looked up by its FQDN or a search is
+	//   read by its ID afterwards.
+	hostId := d.Get("host_id").(int)
+	if hostId == 0 {
+		search := d.Get("search").(string)
+		if fqdn, ok := d.GetOk("fqdn"); ok {
+			search = `name="` + fqdn.(string) + `"`
+		}
+
+		var searchErr error
+		if hostId, searchErr = searchForemanHostId(client, search); searchErr != nil {
+			return searchErr
+		}
+	}
+
+	readHost, readErr := client.ReadHost(hostId)
+	if readErr != nil {
+		return readErr
+	}
+
+	log.Debugf("Read ForemanHost: [%+v]", readHost)
+
+	setDataSourceFromForemanHost(d, readHost)
+
+	return nil
+}
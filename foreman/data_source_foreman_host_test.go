@@ -0,0 +1,303 @@
+package foreman
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+// Given a host ID, create a mock instance state reference for a host data
+// source looking up the host by its ID
+func ForemanHostIdToDataSourceInstanceState(id int) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	attr := map[string]string{}
+	attr["host_id"] = strconv.Itoa(id)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a host data source, create a mock
+// ResourceData reference.
+func MockForemanHostDataSourceResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := dataSourceForemanHost()
+	return r.Data(s)
+}
+
+// Creates a mock ResourceData reference for a host data source looking up the
+// host by its FQDN
+func MockForemanHostDataSourceResourceDataByFQDN(fqdn string) *schema.ResourceData {
+	rd := MockForemanHostDataSourceResourceData(nil)
+	rd.Set("fqdn", fqdn)
+	return rd
+}
+
+// Reads the JSON for the file at the path and creates a host data source
+// ResourceData reference
+func MockForemanHostDataSourceResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanHost
+	ParseJSONFile(t, path, &obj)
+	rd := MockForemanHostDataSourceResourceData(nil)
+	setDataSourceFromForemanHost(rd, &obj)
+	return rd
+}
+
+// Compares two ResourceData references for a host data source.  If the two
+// references differ in their attributes, the test will raise a fatal.
+func ForemanHostDataSourceResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := dataSourceForemanHost()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	attr1 := r1.Get("interfaces_attributes").(*schema.Set)
+	attr2 := r2.Get("interfaces_attributes").(*schema.Set)
+	if !attr1.Equal(attr2) {
+		t.Fatalf(
+			"ResourceData references differ in interfaces_attributes. "+
+				"[%v], [%v]",
+			attr1.List(),
+			attr2.List(),
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// dataSourceForemanHostRead
+// -----------------------------------------------------------------------------
+
+// Ensures a host looked up by its FQDN is searched by name and then read by
+// the ID of the search result
+func TestDataSourceForemanHostRead_FQDN(t *testing.T) {
+
+	fqdn := "foremanterraformtest.dev.company.com"
+	rd := MockForemanHostDataSourceResourceDataByFQDN(fqdn)
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		expectedSearch := `name="` + fqdn + `"`
+		if search := r.URL.Query().Get("search"); search != expectedSearch {
+			t.Errorf(
+				"dataSourceForemanHostRead did not search the host by name. "+
+					"Expected [%s], got [%s]",
+				expectedSearch,
+				search,
+			)
+		}
+		w.Write([]byte(`{
+			"total": 1, "subtotal": 1, "page": 1, "per_page": 20, "search": "",
+			"results": [{"id": 34068, "name": "` + fqdn + `"}]
+		}`))
+	})
+	mux.HandleFunc(HostsURI+"/34068", func(w http.ResponseWriter, r *http.Request) {
+		bytes, readErr := ioutil.ReadFile(HostsTestDataPath + "/read_response.json")
+		if readErr != nil {
+			t.Fatalf("Error reading the read response: [%s]", readErr)
+		}
+		w.Write(bytes)
+	})
+
+	readErr := dataSourceForemanHostRead(rd, client)
+	if readErr != nil {
+		t.Fatalf(
+			"dataSourceForemanHostRead returned an error. Expected [nil], got [%s]",
+			readErr,
+		)
+	}
+
+	expected := map[string]interface{}{
+		"fqdn":               fqdn,
+		"host_id":            34068,
+		"name":               "foremanterraformtest",
+		"hostgroup_id":       98,
+		"hostgroup_name":     "DC1/VM",
+		"build_status":       api.HostBuildStatusPending,
+		"build_status_label": "Pending installation",
+	}
+	for key, value := range expected {
+		if actual := rd.Get(key); actual != value {
+			t.Errorf(
+				"dataSourceForemanHostRead did not set [%s]. Expected [%v], got [%v]",
+				key,
+				value,
+				actual,
+			)
+		}
+	}
+	if rd.Id() != "34068" {
+		t.Errorf(
+			"dataSourceForemanHostRead did not set the ID. Expected [34068], got [%s]",
+			rd.Id(),
+		)
+	}
+	if ifaces := rd.Get("interfaces_attributes").(*schema.Set); ifaces.Len() != 1 {
+		t.Errorf(
+			"dataSourceForemanHostRead did not set the interfaces. Expected [1] "+
+				"interface, got [%d]",
+			ifaces.Len(),
+		)
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanHostCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	id := rand.Intn(100) + 1
+	s := ForemanHostIdToDataSourceInstanceState(id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanHostRead",
+				crudFunc:     dataSourceForemanHostRead,
+				resourceData: MockForemanHostDataSourceResourceData(s),
+			},
+			expectedURI:    fmt.Sprintf("%s/%d", HostsURI, id),
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanHostRead",
+				crudFunc:     dataSourceForemanHostRead,
+				resourceData: MockForemanHostDataSourceResourceDataByFQDN("host01.dev.dc1.company.com"),
+			},
+			expectedURI:    HostsURI,
+			expectedMethod: http.MethodGet,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanHostRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	s := ForemanHostIdToDataSourceInstanceState(rand.Intn(100) + 1)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanHostRead",
+			crudFunc:     dataSourceForemanHostRead,
+			resourceData: MockForemanHostDataSourceResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanHostStatusCodeTestCases(t *testing.T) []TestCase {
+
+	s := ForemanHostIdToDataSourceInstanceState(rand.Intn(100) + 1)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanHostRead",
+			crudFunc:     dataSourceForemanHostRead,
+			resourceData: MockForemanHostDataSourceResourceData(s),
+		},
+		TestCase{
+			funcName:     "dataSourceForemanHostRead",
+			crudFunc:     dataSourceForemanHostRead,
+			resourceData: MockForemanHostDataSourceResourceDataByFQDN("host01.dev.dc1.company.com"),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanHostEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	s := ForemanHostIdToDataSourceInstanceState(rand.Intn(100) + 1)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanHostRead",
+			crudFunc:     dataSourceForemanHostRead,
+			resourceData: MockForemanHostDataSourceResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanHostMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	s := ForemanHostIdToDataSourceInstanceState(34068)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response for a host looked
+		// up by its ID, the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanHostRead",
+				crudFunc:     dataSourceForemanHostRead,
+				resourceData: MockForemanHostDataSourceResourceData(s),
+			},
+			responseFile: HostsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanHostDataSourceResourceDataFromFile(
+				t,
+				HostsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanHostDataSourceResourceDataCompare,
+		},
+		// If the server responds with zero search results for a host looked up
+		// by its FQDN, the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanHostRead",
+				crudFunc:     dataSourceForemanHostRead,
+				resourceData: MockForemanHostDataSourceResourceDataByFQDN("host01.dev.dc1.company.com"),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with more than one search result for a host
+		// looked up by its FQDN, the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanHostRead",
+				crudFunc:     dataSourceForemanHostRead,
+				resourceData: MockForemanHostDataSourceResourceDataByFQDN("host01.dev.dc1.company.com"),
+			},
+			responseFile: HostsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+	}
+
+}
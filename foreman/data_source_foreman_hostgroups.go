@@ -0,0 +1,121 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanHostgroups() *schema.Resource {
+	ds := searchDataSourceSchema(
+		"Hostgroups matching a Foreman scoped search expression.",
+	)
+
+	ds["hostgroups"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup.",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the hostgroup.",
+				},
+				"title": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Title (fullname) of the hostgroup.",
+				},
+				"parent_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup's parent.",
+				},
+				"domain_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup's domain.",
+				},
+				"environment_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup's environment.",
+				},
+				"operatingsystem_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup's operating system.",
+				},
+				"subnet_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the hostgroup's subnet.",
+				},
+			},
+		},
+		Description: "Hostgroups matching the search, in the order returned " +
+			"by Foreman.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostgroupsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostgroupsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_hostgroups.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+	order := d.Get("order").(string)
+
+	log.Debugf("search: [%s], order: [%s]", search, order)
+
+	queryResponse, queryErr := client.SearchHostgroups(search, order)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	hostgroups := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		h, ok := result.(api.ForemanHostgroup)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanHostgroup], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, h.Id)
+		hostgroups = append(hostgroups, map[string]interface{}{
+			"id":                 h.Id,
+			"name":               h.Name,
+			"title":              h.Title,
+			"parent_id":          h.ParentId,
+			"domain_id":          h.DomainId,
+			"environment_id":     h.EnvironmentId,
+			"operatingsystem_id": h.OperatingSystemId,
+			"subnet_id":          h.SubnetId,
+		})
+	}
+
+	log.Debugf("ids: [%v]", ids)
+
+	d.SetId(searchDataSourceId(search, order))
+	d.Set("ids", ids)
+	d.Set("hostgroups", hostgroups)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanHosts() *schema.Resource {
+	ds := searchDataSourceSchema(
+		"Hosts matching a Foreman scoped search expression.",
+	)
+
+	ds["hosts"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host.",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the host without the domain.",
+				},
+				"domain_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the host's domain.",
+				},
+				"comment": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Additional information about the host.",
+				},
+				"domain_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's domain.",
+				},
+				"environment_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's environment.",
+				},
+				"hostgroup_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's hostgroup.",
+				},
+				"operatingsystem_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's operating system.",
+				},
+				"location_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's location.",
+				},
+				"organization_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the host's organization.",
+				},
+			},
+		},
+		Description: "Hosts matching the search, in the order returned by Foreman.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanHostsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_hosts.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+	order := d.Get("order").(string)
+
+	log.Debugf("search: [%s], order: [%s]", search, order)
+
+	queryResponse, queryErr := client.SearchHosts(search, order)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	hosts := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		h, ok := result.(api.ForemanHost)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanHost], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, h.Id)
+		hosts = append(hosts, map[string]interface{}{
+			"id":                 h.Id,
+			"name":               h.Name,
+			"domain_name":        h.DomainName,
+			"comment":            h.Comment,
+			"domain_id":          h.DomainId,
+			"environment_id":     h.EnvironmentId,
+			"hostgroup_id":       h.HostgroupId,
+			"operatingsystem_id": h.OperatingSystemId,
+			"location_id":        h.LocationId,
+			"organization_id":    h.OrganizationId,
+		})
+	}
+
+	log.Debugf("ids: [%v]", ids)
+
+	d.SetId(searchDataSourceId(search, order))
+	d.Set("ids", ids)
+	d.Set("hosts", hosts)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanOperatingSystems() *schema.Resource {
+	ds := searchDataSourceSchema(
+		"Operating systems matching a Foreman scoped search expression.",
+	)
+
+	ds["operatingsystems"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the operating system.",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the operating system.",
+				},
+				"title": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Title (fullname) of the operating system.",
+				},
+				"major": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Major version of the operating system.",
+				},
+				"minor": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Minor version of the operating system.",
+				},
+				"family": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Operating system family.",
+				},
+			},
+		},
+		Description: "Operating systems matching the search, in the order " +
+			"returned by Foreman.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanOperatingSystemsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanOperatingSystemsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_operatingsystems.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+	order := d.Get("order").(string)
+
+	log.Debugf("search: [%s], order: [%s]", search, order)
+
+	queryResponse, queryErr := client.SearchOperatingSystems(search, order)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	operatingSystems := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		o, ok := result.(api.ForemanOperatingSystem)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanOperatingSystem], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, o.Id)
+		operatingSystems = append(operatingSystems, map[string]interface{}{
+			"id":     o.Id,
+			"name":   o.Name,
+			"title":  o.Title,
+			"major":  o.Major,
+			"minor":  o.Minor,
+			"family": o.Family,
+		})
+	}
+
+	log.Debugf("ids: [%v]", ids)
+
+	d.SetId(searchDataSourceId(search, order))
+	d.Set("ids", ids)
+	d.Set("operatingsystems", operatingSystems)
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanPermission() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanPermissionRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Looks up permissions by name or resource type. Permissions "+
+						"are defined by Foreman and its plugins and are granted "+
+						"through filters.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "resource_type"},
+				Description: fmt.Sprintf(
+					"Name of the permission. Exactly one permission must match if "+
+						"set. "+
+						"%s \"view_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"resource_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "resource_type"},
+				Description: fmt.Sprintf(
+					"Resource type of the permissions. If `name` is not set, all "+
+						"permissions of the resource type are looked up. "+
+						"%s \"Host\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the matching permissions, ie: for use as the " +
+					"`permission_ids` of a filter.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanPermission constructs a ForemanPermission reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanPermission(d *schema.ResourceData) *api.ForemanPermission {
+	log.Tracef("data_source_foreman_permission.go#buildForemanPermission")
+
+	permission := api.ForemanPermission{}
+
+	obj := buildForemanObject(d)
+	permission.ForemanObject = *obj
+
+	if attr, ok := d.GetOk("resource_type"); ok {
+		permission.ResourceType = attr.(string)
+	}
+
+	return &permission
+}
+
+// setResourceDataFromForemanPermission sets a ResourceData's attributes from
+// the attributes of the supplied ForemanPermission reference
+func setResourceDataFromForemanPermission(d *schema.ResourceData, fp *api.ForemanPermission) {
+	log.Tracef("data_source_foreman_permission.go#setResourceDataFromForemanPermission")
+
+	d.SetId(strconv.Itoa(fp.Id))
+	d.Set("name", fp.Name)
+	d.Set("resource_type", fp.ResourceType)
+	d.Set("ids", []int{fp.Id})
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanPermissionRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_permission.go#Read")
+
+	client := meta.(*api.Client)
+	p := buildForemanPermission(d)
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	queryResponse, queryErr := client.QueryPermission(p)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source permission returned no results")
+	} else if queryResponse.Subtotal > 1 && p.Name != "" {
+		return fmt.Errorf("Data source permission returned more than 1 result")
+	}
+
+	permissions := make([]api.ForemanPermission, len(queryResponse.Results))
+	for idx, result := range queryResponse.Results {
+		var ok bool
+		if permissions[idx], ok = result.(api.ForemanPermission); !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanPermission], got [%T]",
+				result,
+			)
+		}
+	}
+
+	log.Debugf("ForemanPermissions: [%+v]", permissions)
+
+	if len(permissions) == 1 {
+		setResourceDataFromForemanPermission(d, &permissions[0])
+		return nil
+	}
+
+	// NOTE(ALL): all permissions of a resource type were looked up, there is
+	//   no single permission to take the ID and name from
+	ids := make([]int, len(permissions))
+	for idx, permission := range permissions {
+		ids[idx] = permission.Id
+	}
+	d.SetId(searchDataSourceId("resource_type="+p.ResourceType, ""))
+	d.Set("name", "")
+	d.Set("ids", ids)
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package foreman
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const PermissionsURI = api.FOREMAN_API_URL_PREFIX + "/permissions"
+const PermissionsTestDataPath = "testdata/1.11/permissions"
+
+// Given a ForemanPermission, create a mock instance state reference
+func ForemanPermissionToInstanceState(obj api.ForemanPermission) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanPermission
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["resource_type"] = obj.ResourceType
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanPermission resource, create a
+// mock ResourceData reference.
+func MockForemanPermissionResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := dataSourceForemanPermission()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a permission
+// ResourceData reference
+func MockForemanPermissionResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanPermission
+	ParseJSONFile(t, path, &obj)
+	s := ForemanPermissionToInstanceState(obj)
+	rd := MockForemanPermissionResourceData(s)
+	rd.Set("ids", []int{obj.Id})
+	return rd
+}
+
+// Creates a random ForemanPermission struct
+func RandForemanPermission() api.ForemanPermission {
+	obj := api.ForemanPermission{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+	obj.ResourceType = tfrand.String(10, tfrand.Lower)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanPermission resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanPermissionResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := dataSourceForemanPermission()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	ids1 := r1.Get("ids").([]interface{})
+	ids2 := r2.Get("ids").([]interface{})
+	if !reflect.DeepEqual(ids1, ids2) {
+		t.Fatalf(
+			"ResourceData references differ in ids. [%v], [%v]",
+			ids1,
+			ids2,
+		)
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanPermissionCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanPermission()
+	s := ForemanPermissionToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanPermissionRead",
+				crudFunc:     dataSourceForemanPermissionRead,
+				resourceData: MockForemanPermissionResourceData(s),
+			},
+			expectedURI:    PermissionsURI,
+			expectedMethod: http.MethodGet,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanPermissionRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	obj := RandForemanPermission()
+	s := ForemanPermissionToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanPermissionRead",
+			crudFunc:     dataSourceForemanPermissionRead,
+			resourceData: MockForemanPermissionResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanPermissionStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPermission()
+	s := ForemanPermissionToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanPermissionRead",
+			crudFunc:     dataSourceForemanPermissionRead,
+			resourceData: MockForemanPermissionResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanPermissionEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPermission()
+	s := ForemanPermissionToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanPermissionRead",
+			crudFunc:     dataSourceForemanPermissionRead,
+			resourceData: MockForemanPermissionResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanPermissionMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanPermission()
+	s := ForemanPermissionToInstanceState(obj)
+
+	// looking up all permissions of a resource type
+	resourceTypeObj := RandForemanPermission()
+	resourceTypeObj.Name = ""
+	resourceTypeState := ForemanPermissionToInstanceState(resourceTypeObj)
+
+	resourceTypeRd := MockForemanPermissionResourceData(resourceTypeState)
+	resourceTypeRd.SetId(searchDataSourceId("resource_type="+resourceTypeObj.ResourceType, ""))
+	resourceTypeRd.Set("name", "")
+	resourceTypeRd.Set("ids", []int{74, 75})
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for a lookup
+		// by name, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanPermissionRead",
+				crudFunc:     dataSourceForemanPermissionRead,
+				resourceData: MockForemanPermissionResourceData(s),
+			},
+			responseFile: PermissionsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with more than one search result for a lookup
+		// by resource type only, then the operation should succeed and set the
+		// IDs of all matching permissions
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanPermissionRead",
+				crudFunc:     dataSourceForemanPermissionRead,
+				resourceData: MockForemanPermissionResourceData(resourceTypeState),
+			},
+			responseFile:         PermissionsTestDataPath + "/query_response_multi.json",
+			returnError:          false,
+			expectedResourceData: resourceTypeRd,
+			compareFunc:          ForemanPermissionResourceDataCompare,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanPermissionRead",
+				crudFunc:     dataSourceForemanPermissionRead,
+				resourceData: MockForemanPermissionResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanPermissionRead",
+				crudFunc:     dataSourceForemanPermissionRead,
+				resourceData: MockForemanPermissionResourceData(s),
+			},
+			responseFile: PermissionsTestDataPath + "/query_response_single.json",
+			returnError:  false,
+			expectedResourceData: MockForemanPermissionResourceDataFromFile(
+				t,
+				PermissionsTestDataPath+"/query_response_single_state.json",
+			),
+			compareFunc: ForemanPermissionResourceDataCompare,
+		},
+	}
+
+}
@@ -0,0 +1,74 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanRealm() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanRealm()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the realm. "+
+				"%s \"ACME\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanRealmRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanRealmRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_realm.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	queryResponse, queryErr := client.QueryRealm(r)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source realm returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return fmt.Errorf("Data source realm returned more than 1 result")
+	}
+
+	var queryRealm api.ForemanRealm
+	var ok bool
+	if queryRealm, ok = queryResponse.Results[0].(api.ForemanRealm); !ok {
+		return fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanRealm], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	r = &queryRealm
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	setResourceDataFromForemanRealm(d, r)
+
+	return nil
+}
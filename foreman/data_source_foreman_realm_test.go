@@ -0,0 +1,127 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanRealmCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRealmRead",
+				crudFunc:     dataSourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedURI:    RealmsURI,
+			expectedMethod: http.MethodGet,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanRealmRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanRealmRead",
+			crudFunc:     dataSourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanRealmStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanRealmRead",
+			crudFunc:     dataSourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanRealmEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanRealmRead",
+			crudFunc:     dataSourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanRealmMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRealmRead",
+				crudFunc:     dataSourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: RealmsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRealmRead",
+				crudFunc:     dataSourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRealmRead",
+				crudFunc:     dataSourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: RealmsTestDataPath + "/query_response_single.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRealmResourceDataFromFile(
+				t,
+				RealmsTestDataPath+"/query_response_single_state.json",
+			),
+			compareFunc: ForemanRealmResourceDataCompare,
+		},
+	}
+
+}
@@ -0,0 +1,74 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanRole() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanRole()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the role. "+
+				"%s \"ACME\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanRoleRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanRoleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	queryResponse, queryErr := client.QueryRole(r)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source role returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return fmt.Errorf("Data source role returned more than 1 result")
+	}
+
+	var queryRole api.ForemanRole
+	var ok bool
+	if queryRole, ok = queryResponse.Results[0].(api.ForemanRole); !ok {
+		return fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanRole], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	r = &queryRole
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	setResourceDataFromForemanRole(d, r)
+
+	return nil
+}
@@ -16,6 +16,10 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 	r := resourceForemanSmartProxy()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
 
+	// refreshing and requiring features only applies to managed smart proxies
+	delete(ds, "refresh_trigger")
+	delete(ds, "required_features")
+
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
 		Type:     schema.TypeString,
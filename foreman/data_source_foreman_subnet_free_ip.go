@@ -0,0 +1,97 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceForemanSubnetFreeIp() *schema.Resource {
+	return &schema.Resource{
+
+		Read: dataSourceForemanSubnetFreeIpRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Suggests the next free IP address of a subnet. The "+
+						"suggestion honours the IPAM mode and the from/to range of the "+
+						"subnet. Foreman suggests a different address once the returned "+
+						"one is in use, so hosts using it should ignore later changes "+
+						"of their interface IP.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"subnet_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the subnet to suggest the IP address from.",
+			},
+
+			"mac": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"MAC address of the interface the IP address is for. Only "+
+						"used by the EUI-64 IPAM mode. "+
+						"%s \"c0:ff:ee:ba:be:00\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"excluded_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.SingleIP(),
+				},
+				Description: "IP addresses which must not be suggested, ie: the " +
+					"addresses already taken by other hosts of the same plan.",
+			},
+
+			"ip": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The suggested free IP address.",
+			},
+		},
+	}
+}
+
+func dataSourceForemanSubnetFreeIpRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_subnet_free_ip.go#Read")
+
+	client := meta.(*api.Client)
+
+	subnetId := d.Get("subnet_id").(int)
+	mac := d.Get("mac").(string)
+	excludedIps := []string{}
+	for _, ip := range d.Get("excluded_ips").([]interface{}) {
+		excludedIps = append(excludedIps, ip.(string))
+	}
+
+	log.Debugf("subnetId: [%d], mac: [%s], excludedIps: [%v]", subnetId, mac, excludedIps)
+
+	freeIp, suggestErr := client.SuggestSubnetFreeIp(subnetId, mac, excludedIps)
+	if suggestErr != nil {
+		return suggestErr
+	}
+
+	log.Debugf("freeIp: [%s]", freeIp)
+
+	d.SetId(fmt.Sprintf("%d/%s", subnetId, freeIp))
+	d.Set("ip", freeIp)
+
+	return nil
+}
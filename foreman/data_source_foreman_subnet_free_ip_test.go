@@ -0,0 +1,243 @@
+package foreman
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+// Given a subnet ID, create a mock instance state reference for a free IP
+// data source
+func ForemanSubnetFreeIpToInstanceState(subnetId int, ip string) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	if ip != "" {
+		state.ID = fmt.Sprintf("%d/%s", subnetId, ip)
+	}
+	attr := map[string]string{}
+	attr["subnet_id"] = strconv.Itoa(subnetId)
+	attr["ip"] = ip
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a free IP data source, create a mock
+// ResourceData reference.
+func MockForemanSubnetFreeIpResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := dataSourceForemanSubnetFreeIp()
+	return r.Data(s)
+}
+
+// Compares two ResourceData references for a free IP data source.  If the two
+// references differ in their attributes, the test will raise a fatal.
+func ForemanSubnetFreeIpResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := dataSourceForemanSubnetFreeIp()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// dataSourceForemanSubnetFreeIpRead
+// -----------------------------------------------------------------------------
+
+// Ensures the MAC address and the excluded IPs are sent as query parameters
+func TestDataSourceForemanSubnetFreeIpRead_QueryParameters(t *testing.T) {
+
+	subnetId := rand.Intn(100) + 1
+	s := ForemanSubnetFreeIpToInstanceState(subnetId, "")
+	s.Attributes["mac"] = "c0:ff:ee:ba:be:00"
+	s.Attributes["excluded_ips.#"] = "2"
+	s.Attributes["excluded_ips.0"] = "10.228.247.10"
+	s.Attributes["excluded_ips.1"] = "10.228.247.11"
+	rd := MockForemanSubnetFreeIpResourceData(s)
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	uri := fmt.Sprintf("%s/%d/freeip", SubnetsURI, subnetId)
+	mux.HandleFunc(uri, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("mac") != "c0:ff:ee:ba:be:00" {
+			t.Errorf(
+				"dataSourceForemanSubnetFreeIpRead did not send the MAC address. "+
+					"Expected [c0:ff:ee:ba:be:00], got [%s]",
+				query.Get("mac"),
+			)
+		}
+		expectedIps := []string{"10.228.247.10", "10.228.247.11"}
+		if !reflect.DeepEqual(query["excluded_ips[]"], expectedIps) {
+			t.Errorf(
+				"dataSourceForemanSubnetFreeIpRead did not send the excluded IPs. "+
+					"Expected [%v], got [%v]",
+				expectedIps,
+				query["excluded_ips[]"],
+			)
+		}
+		w.Write([]byte(`{"freeip": "10.228.247.12"}`))
+	})
+
+	readErr := dataSourceForemanSubnetFreeIpRead(rd, client)
+	if readErr != nil {
+		t.Fatalf(
+			"dataSourceForemanSubnetFreeIpRead returned an error. Expected [nil], "+
+				"got [%s]",
+			readErr,
+		)
+	}
+	if rd.Get("ip").(string) != "10.228.247.12" {
+		t.Fatalf(
+			"dataSourceForemanSubnetFreeIpRead did not set the suggested IP. "+
+				"Expected [10.228.247.12], got [%s]",
+			rd.Get("ip").(string),
+		)
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanSubnetFreeIpCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	subnetId := rand.Intn(100) + 1
+	s := ForemanSubnetFreeIpToInstanceState(subnetId, "")
+	freeIpURI := fmt.Sprintf("%s/%d/freeip", SubnetsURI, subnetId)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSubnetFreeIpRead",
+				crudFunc:     dataSourceForemanSubnetFreeIpRead,
+				resourceData: MockForemanSubnetFreeIpResourceData(s),
+			},
+			expectedURI:    freeIpURI,
+			expectedMethod: http.MethodGet,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanSubnetFreeIpRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	s := ForemanSubnetFreeIpToInstanceState(rand.Intn(100)+1, "")
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanSubnetFreeIpRead",
+			crudFunc:     dataSourceForemanSubnetFreeIpRead,
+			resourceData: MockForemanSubnetFreeIpResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanSubnetFreeIpStatusCodeTestCases(t *testing.T) []TestCase {
+
+	s := ForemanSubnetFreeIpToInstanceState(rand.Intn(100)+1, "")
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanSubnetFreeIpRead",
+			crudFunc:     dataSourceForemanSubnetFreeIpRead,
+			resourceData: MockForemanSubnetFreeIpResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanSubnetFreeIpEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	s := ForemanSubnetFreeIpToInstanceState(rand.Intn(100)+1, "")
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanSubnetFreeIpRead",
+			crudFunc:     dataSourceForemanSubnetFreeIpRead,
+			resourceData: MockForemanSubnetFreeIpResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanSubnetFreeIpMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	subnetId := rand.Intn(100) + 1
+	s := ForemanSubnetFreeIpToInstanceState(subnetId, "")
+	expectedState := ForemanSubnetFreeIpToInstanceState(subnetId, "10.228.247.12")
+
+	return []TestCaseMockResponse{
+		// If the server responds with a suggested IP, the operation should
+		// succeed and set the IP
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSubnetFreeIpRead",
+				crudFunc:     dataSourceForemanSubnetFreeIpRead,
+				resourceData: MockForemanSubnetFreeIpResourceData(s),
+			},
+			responseFile:         SubnetsTestDataPath + "/freeip_response.json",
+			returnError:          false,
+			expectedResourceData: MockForemanSubnetFreeIpResourceData(expectedState),
+			compareFunc:          ForemanSubnetFreeIpResourceDataCompare,
+		},
+		// Older Foreman versions return the suggested IP as "ip"
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSubnetFreeIpRead",
+				crudFunc:     dataSourceForemanSubnetFreeIpRead,
+				resourceData: MockForemanSubnetFreeIpResourceData(s),
+			},
+			responseFile:         SubnetsTestDataPath + "/freeip_response_legacy.json",
+			returnError:          false,
+			expectedResourceData: MockForemanSubnetFreeIpResourceData(expectedState),
+			compareFunc:          ForemanSubnetFreeIpResourceDataCompare,
+		},
+		// If the server does not suggest an IP, the operation should return
+		// an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSubnetFreeIpRead",
+				crudFunc:     dataSourceForemanSubnetFreeIpRead,
+				resourceData: MockForemanSubnetFreeIpResourceData(s),
+			},
+			responseFile: SubnetsTestDataPath + "/read_response.json",
+			returnError:  true,
+		},
+	}
+
+}
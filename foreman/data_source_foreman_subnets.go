@@ -0,0 +1,112 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanSubnets() *schema.Resource {
+	ds := searchDataSourceSchema(
+		"Subnets matching a Foreman scoped search expression.",
+	)
+
+	ds["subnets"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the subnet.",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the subnet.",
+				},
+				"network": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Network address of the subnet.",
+				},
+				"mask": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Netmask of the subnet.",
+				},
+				"gateway": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Gateway of the subnet.",
+				},
+				"domain_ids": &schema.Schema{
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+					Description: "IDs of the domains of the subnet.",
+				},
+			},
+		},
+		Description: "Subnets matching the search, in the order returned by " +
+			"Foreman.",
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanSubnetsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanSubnetsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_subnets.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+	order := d.Get("order").(string)
+
+	log.Debugf("search: [%s], order: [%s]", search, order)
+
+	queryResponse, queryErr := client.SearchSubnets(search, order)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	subnets := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		s, ok := result.(api.ForemanSubnet)
+		if !ok {
+			return fmt.Errorf(
+				"Data source results contain unexpected type. Expected "+
+					"[api.ForemanSubnet], got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, s.Id)
+		subnets = append(subnets, map[string]interface{}{
+			"id":         s.Id,
+			"name":       s.Name,
+			"network":    s.Network,
+			"mask":       s.Mask,
+			"gateway":    s.Gateway,
+			"domain_ids": s.Domains,
+		})
+	}
+
+	log.Debugf("ids: [%v]", ids)
+
+	d.SetId(searchDataSourceId(search, order))
+	d.Set("ids", ids)
+	d.Set("subnets", subnets)
+
+	return nil
+}
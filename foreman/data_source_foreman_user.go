@@ -0,0 +1,77 @@
+package foreman
+
+import (
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceForemanUser() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanUser()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// the password is never returned by Foreman
+	delete(ds, "password")
+
+	// define searchable attributes for the data source
+	ds["login"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The login name of the user. "+
+				"%s \"jsmith\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceForemanUserRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanUserRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("data_source_foreman_user.go#Read")
+
+	client := meta.(*api.Client)
+	u := buildForemanUser(d)
+
+	log.Debugf("ForemanUser: [%+v]", u)
+
+	queryResponse, queryErr := client.QueryUser(u)
+	if queryErr != nil {
+		return queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return fmt.Errorf("Data source user returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return fmt.Errorf("Data source user returned more than 1 result")
+	}
+
+	var queryUser api.ForemanUser
+	var ok bool
+	if queryUser, ok = queryResponse.Results[0].(api.ForemanUser); !ok {
+		return fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanUser], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	u = &queryUser
+
+	log.Debugf("ForemanUser: [%+v]", u)
+
+	setResourceDataFromForemanUser(d, u)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanUserCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanUserRead",
+				crudFunc:     dataSourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedURI:    UsersURI,
+			expectedMethod: http.MethodGet,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanUserRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanUserRead",
+			crudFunc:     dataSourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanUserStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanUserRead",
+			crudFunc:     dataSourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanUserEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "dataSourceForemanUserRead",
+			crudFunc:     dataSourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanUserMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanUserRead",
+				crudFunc:     dataSourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: UsersTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanUserRead",
+				crudFunc:     dataSourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanUserRead",
+				crudFunc:     dataSourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: UsersTestDataPath + "/query_response_single.json",
+			returnError:  false,
+			expectedResourceData: MockForemanUserResourceDataFromFile(
+				t,
+				UsersTestDataPath+"/query_response_single_state.json",
+			),
+			compareFunc: ForemanUserResourceDataCompare,
+		},
+	}
+
+}
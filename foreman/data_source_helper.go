@@ -0,0 +1,67 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// searchDataSourceSchema returns the attributes shared by the data sources
+// which search Foreman with a scoped search expression and return a list of
+// matching objects.  The summary is used as the autodoc summary of the data
+// source.  The caller adds the attribute holding the list of objects.
+func searchDataSourceSchema(summary string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s %s",
+				autodoc.MetaSummary,
+				summary,
+			),
+		},
+
+		"search": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"A Foreman scoped search expression. When empty, all objects "+
+					"are returned. "+
+					"%s \"os = RedHat and environment = production\"",
+				autodoc.MetaExample,
+			),
+		},
+
+		"order": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"Sort order of the results in the form of \"<field> <ASC|DESC>\". "+
+					"%s \"name DESC\"",
+				autodoc.MetaExample,
+			),
+		},
+
+		"ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+			Description: "IDs of the objects matching the search, in the order " +
+				"returned by Foreman.",
+		},
+	}
+}
+
+// searchDataSourceId creates a stable ID for a search data source from its
+// search and order expressions.
+func searchDataSourceId(search string, order string) string {
+	return strconv.Itoa(hashcode.String(search + "|" + order))
+}
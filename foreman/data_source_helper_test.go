@@ -0,0 +1,286 @@
+package foreman
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+// The search and order expressions the mock search data sources are read
+// with
+const (
+	SearchDataSourceSearch = "name ~ company.com"
+	SearchDataSourceOrder  = "name ASC"
+)
+
+// searchDataSourceTestCase describes a data source which searches Foreman
+// with a scoped search expression and returns a list of matching objects
+type searchDataSourceTestCase struct {
+	// The name of the data source's read function - used when generating
+	// errors/fatals during unit tests
+	funcName string
+	// The data source and its read function
+	dataSource func() *schema.Resource
+	readFunc   CRUDFunc
+	// The URI the data source searches and the test data folder holding the
+	// mock query responses for it
+	uri          string
+	testDataPath string
+	// The attribute holding the list of matching objects and the expected
+	// attributes of the first object of "query_response_multi.json"
+	listKey       string
+	expectedFirst map[string]string
+}
+
+// searchDataSourceTestCases returns a test case for every search data source
+func searchDataSourceTestCases() []searchDataSourceTestCase {
+	return []searchDataSourceTestCase{
+		{
+			funcName:     "dataSourceForemanDomainsRead",
+			dataSource:   dataSourceForemanDomains,
+			readFunc:     dataSourceForemanDomainsRead,
+			uri:          DomainsURI,
+			testDataPath: DomainsTestDataPath,
+			listKey:      "domains",
+			expectedFirst: map[string]string{
+				"id":   "35",
+				"name": "dev.dc1.company.com",
+			},
+		},
+		{
+			funcName:     "dataSourceForemanHostgroupsRead",
+			dataSource:   dataSourceForemanHostgroups,
+			readFunc:     dataSourceForemanHostgroupsRead,
+			uri:          HostgroupsURI,
+			testDataPath: HostgroupsTestDataPath,
+			listKey:      "hostgroups",
+			expectedFirst: map[string]string{
+				"id":                 "106",
+				"name":               "dc1_web",
+				"title":              "DC1/dc1_web",
+				"parent_id":          "97",
+				"environment_id":     "5818",
+				"operatingsystem_id": "23",
+			},
+		},
+		{
+			funcName:     "dataSourceForemanHostsRead",
+			dataSource:   dataSourceForemanHosts,
+			readFunc:     dataSourceForemanHostsRead,
+			uri:          HostsURI,
+			testDataPath: HostsTestDataPath,
+			listKey:      "hosts",
+			expectedFirst: map[string]string{
+				"id":              "1182",
+				"name":            "app01",
+				"domain_name":     "dev.company.com",
+				"hostgroup_id":    "98",
+				"organization_id": "3",
+			},
+		},
+		{
+			funcName:     "dataSourceForemanOperatingSystemsRead",
+			dataSource:   dataSourceForemanOperatingSystems,
+			readFunc:     dataSourceForemanOperatingSystemsRead,
+			uri:          OperatingSystemsURI,
+			testDataPath: OperatingSystemsTestDataPath,
+			listKey:      "operatingsystems",
+			expectedFirst: map[string]string{
+				"id":     "2",
+				"name":   "CentOS",
+				"title":  "CentOS 6.3",
+				"major":  "6",
+				"minor":  "3",
+				"family": "Redhat",
+			},
+		},
+		{
+			funcName:     "dataSourceForemanSubnetsRead",
+			dataSource:   dataSourceForemanSubnets,
+			readFunc:     dataSourceForemanSubnetsRead,
+			uri:          SubnetsURI,
+			testDataPath: SubnetsTestDataPath,
+			listKey:      "subnets",
+			expectedFirst: map[string]string{
+				"id":      "303",
+				"name":    "10.228.192.0 DC1",
+				"network": "10.228.192.0",
+				"mask":    "255.255.255.0",
+				"gateway": "10.228.192.1",
+			},
+		},
+	}
+}
+
+// Creates a mock ResourceData reference for a search data source with the
+// search and order expressions set
+func MockSearchDataSourceResourceData(r *schema.Resource) *schema.ResourceData {
+	rd := r.Data(nil)
+	rd.Set("search", SearchDataSourceSearch)
+	rd.Set("order", SearchDataSourceOrder)
+	return rd
+}
+
+// -----------------------------------------------------------------------------
+// Search Data Source Read
+// -----------------------------------------------------------------------------
+
+// Ensures the search data sources send the search and order expressions in
+// the query string and set the attributes of the matching objects
+func TestSearchDataSourcesRead(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	for _, testCase := range searchDataSourceTestCases() {
+		mux, server, client := NewForemanAPIAndClient(cred, conf)
+
+		responseFile := testCase.testDataPath + "/query_response_multi.json"
+		response, readErr := ioutil.ReadFile(responseFile)
+		if readErr != nil {
+			server.Close()
+			t.Fatalf("Failed to read the mock response [%s]: [%s]", responseFile, readErr)
+		}
+
+		var search, order string
+		mux.HandleFunc(testCase.uri, func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query().Get("search")
+			order = r.URL.Query().Get("order")
+			w.Write(response)
+		})
+
+		rd := MockSearchDataSourceResourceData(testCase.dataSource())
+		funcErr := testCase.readFunc(rd, client)
+		server.Close()
+
+		if funcErr != nil {
+			t.Errorf("%s returned an error: [%s]", testCase.funcName, funcErr)
+			continue
+		}
+		if search != SearchDataSourceSearch || order != SearchDataSourceOrder {
+			t.Errorf(
+				"%s sent the wrong query. Expected search [%s] and order [%s], "+
+					"got [%s] and [%s]",
+				testCase.funcName,
+				SearchDataSourceSearch,
+				SearchDataSourceOrder,
+				search,
+				order,
+			)
+		}
+
+		expected := MockSearchDataSourceResourceDataFromFile(t, testCase.dataSource(), responseFile)
+		SearchDataSourceResourceDataCompare(t, rd, expected)
+
+		count := rd.Get(testCase.listKey + ".#").(int)
+		if count != len(expected.Get("ids").([]interface{})) {
+			t.Errorf(
+				"%s set [%d] %s, expected one for each ID [%v]",
+				testCase.funcName,
+				count,
+				testCase.listKey,
+				expected.Get("ids"),
+			)
+		}
+		for key, value := range testCase.expectedFirst {
+			attrKey := fmt.Sprintf("%s.0.%s", testCase.listKey, key)
+			if actual := fmt.Sprintf("%v", rd.Get(attrKey)); actual != value {
+				t.Errorf(
+					"%s set the wrong value for [%s]. Expected [%s], got [%s]",
+					testCase.funcName,
+					attrKey,
+					value,
+					actual,
+				)
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func SearchDataSourcesCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	testCases := []TestCaseCorrectURLAndMethod{}
+	for _, testCase := range searchDataSourceTestCases() {
+		testCases = append(testCases, TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     testCase.funcName,
+				crudFunc:     testCase.readFunc,
+				resourceData: MockSearchDataSourceResourceData(testCase.dataSource()),
+			},
+			expectedURI:    testCase.uri,
+			expectedMethod: http.MethodGet,
+		})
+	}
+	return testCases
+
+}
+
+// searchDataSourceReadTestCases returns a TestCase reading every search data
+// source.  The same test cases are used for the request data, status code and
+// empty response tests.
+func searchDataSourceReadTestCases() []TestCase {
+	testCases := []TestCase{}
+	for _, testCase := range searchDataSourceTestCases() {
+		testCases = append(testCases, TestCase{
+			funcName:     testCase.funcName,
+			crudFunc:     testCase.readFunc,
+			resourceData: MockSearchDataSourceResourceData(testCase.dataSource()),
+		})
+	}
+	return testCases
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func SearchDataSourcesRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	return searchDataSourceReadTestCases()
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func SearchDataSourcesStatusCodeTestCases(t *testing.T) []TestCase {
+	return searchDataSourceReadTestCases()
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func SearchDataSourcesEmptyResponseTestCases(t *testing.T) []TestCase {
+	return searchDataSourceReadTestCases()
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func SearchDataSourcesMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	testCases := []TestCaseMockResponse{}
+	for _, testCase := range searchDataSourceTestCases() {
+		// If the server responds with zero search results for the data source
+		// read, then the operation should succeed with an empty list
+		testCases = append(testCases, TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     testCase.funcName,
+				crudFunc:     testCase.readFunc,
+				resourceData: MockSearchDataSourceResourceData(testCase.dataSource()),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  false,
+			expectedResourceData: MockSearchDataSourceResourceDataFromFile(
+				t,
+				testCase.dataSource(),
+				TestDataPath+"/query_response_zero.json",
+			),
+			compareFunc: SearchDataSourceResourceDataCompare,
+		})
+	}
+	return testCases
+
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -15,6 +16,7 @@ import (
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
 // ----------------------------------------------------------------------------
@@ -134,6 +136,38 @@ func CompareResourceDataAttributes(t *testing.T, m map[string]schema.ValueType,
 	} //end for
 }
 
+// MockSearchDataSourceResourceDataFromFile reads the query response JSON at
+// the path and creates a ResourceData reference for the search data source
+// with the "ids" attribute set to the IDs of the query results.
+func MockSearchDataSourceResourceDataFromFile(t *testing.T, r *schema.Resource, path string) *schema.ResourceData {
+	var queryResponse struct {
+		Results []api.ForemanObject `json:"results"`
+	}
+	ParseJSONFile(t, path, &queryResponse)
+	ids := make([]int, len(queryResponse.Results))
+	for idx, val := range queryResponse.Results {
+		ids[idx] = val.Id
+	}
+	rd := r.Data(nil)
+	rd.Set("ids", ids)
+	return rd
+}
+
+// SearchDataSourceResourceDataCompare compares the "ids" attribute of two
+// ResourceData references for a search data source.  If the IDs differ in
+// value or order, the test will raise a fatal.
+func SearchDataSourceResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+	ids1 := r1.Get("ids").([]interface{})
+	ids2 := r2.Get("ids").([]interface{})
+	if !reflect.DeepEqual(ids1, ids2) {
+		t.Fatalf(
+			"ResourceData references differ in ids. [%v], [%v]",
+			ids1,
+			ids2,
+		)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Common Tests and Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -192,6 +226,9 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanArchitectureCorrectURLAndMethodTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanDomainCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanDomainCorrectURLAndMethodTestCases(t)...)
 
@@ -203,6 +240,8 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 	testCases = append(testCases, ResourceForemanHostgroupCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanHostgroupCorrectURLAndMethodTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanHostCorrectURLAndMethodTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanMediaCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaCorrectURLAndMethodTestCases(t)...)
 
@@ -223,6 +262,9 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanSubnetCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSubnetFreeIpCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, SearchDataSourcesCorrectURLAndMethodTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanComputeResourceCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanComputeResourceCorrectURLAndMethodTestCases(t)...)
@@ -232,6 +274,24 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanTemplateKindCorrectURLAndMethodTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanUserCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanUserCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanFilterCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanUsergroupCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanExternalUsergroupCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanAuthSourceLdapCorrectURLAndMethodTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRealmCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRealmCorrectURLAndMethodTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -283,6 +343,9 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanArchitectureRequestDataEmptyTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanDomainRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanDomainRequestDataEmptyTestCases(t)...)
 
@@ -294,6 +357,8 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 	testCases = append(testCases, ResourceForemanHostgroupRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanHostgroupRequestDataEmptyTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanHostRequestDataEmptyTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanMediaRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaRequestDataEmptyTestCases(t)...)
 
@@ -314,9 +379,30 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanSubnetRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSubnetFreeIpRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, SearchDataSourcesRequestDataEmptyTestCases(t)...)
 
 	testCases = append(testCases, DataSourceForemanTemplateKindRequestDataEmptyTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanUserRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanUserRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRoleRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanFilterRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanUsergroupRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanExternalUsergroupRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanAuthSourceLdapRequestDataEmptyTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRealmRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRealmRequestDataEmptyTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -362,6 +448,7 @@ type TestCaseRequestData struct {
 func TestCRUDFunction_RequestData(t *testing.T) {
 	testCases := []TestCaseRequestData{}
 	testCases = append(testCases, ResourceForemanArchitectureRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanHostRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanHostgroupRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanMediaRequestDataTestCases(t)...)
@@ -369,6 +456,14 @@ func TestCRUDFunction_RequestData(t *testing.T) {
 	testCases = append(testCases, ResourceForemanPartitionTableRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanProvisioningTemplateRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanSmartProxyRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanSubnetRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUsergroupRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanExternalUsergroupRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLdapRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRealmRequestDataTestCases(t)...)
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -418,6 +513,9 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanArchitectureStatusCodeTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanOrganizationStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationStatusCodeTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanDomainStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanDomainStatusCodeTestCases(t)...)
 
@@ -429,6 +527,8 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 	testCases = append(testCases, ResourceForemanHostgroupStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanHostgroupStatusCodeTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanHostStatusCodeTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanMediaStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaStatusCodeTestCases(t)...)
 
@@ -449,6 +549,9 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanSubnetStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSubnetFreeIpStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, SearchDataSourcesStatusCodeTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanComputeResourceStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanComputeResourceStatusCodeTestCases(t)...)
@@ -458,6 +561,24 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanTemplateKindStatusCodeTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanUserStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanUserStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRoleStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanFilterStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanUsergroupStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanExternalUsergroupStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanAuthSourceLdapStatusCodeTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRealmStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRealmStatusCodeTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -483,6 +604,57 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 	} //end for
 }
 
+// TestCRUDFunction_NotFound ensures the read function of every resource
+// removes the resource from the state when the mock server responds with a
+// 404 Not Found (ie: the object was deleted outside of terraform).  The test
+// fails if the read function returns an error or the resource keeps its ID.
+func TestCRUDFunction_NotFound(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+
+	resourceNames := make([]string, 0, len(provider.ResourcesMap))
+	for name := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Resource not found by id '1'"}`))
+	})
+
+	for _, name := range resourceNames {
+		t.Logf("resource: [%s]", name)
+
+		r := provider.ResourcesMap[name]
+		resourceData := r.Data(&terraform.InstanceState{ID: "1"})
+
+		err := r.Read(resourceData, client)
+		if err != nil {
+			t.Fatalf(
+				"[%s] read returned an error when the server responded with "+
+					"404 Not Found. Expected [nil] got [%s]",
+				name,
+				err,
+			)
+		}
+		if resourceData.Id() != "" {
+			t.Fatalf(
+				"[%s] read did not remove the resource from the state when the "+
+					"server responded with 404 Not Found. Expected ID [] got [%s]",
+				name,
+				resourceData.Id(),
+			)
+		}
+
+	} //end for
+}
+
 // TestCRUDFunction_EmptyResponseError ensures each of the CRUD functions
 // returns an error when the server's response does not include any data.  The
 // mock server will respond by just setting the response header to a
@@ -493,6 +665,9 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanArchitectureEmptyResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanOrganizationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationEmptyResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanDomainEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanDomainEmptyResponseTestCases(t)...)
 
@@ -504,6 +679,8 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 	testCases = append(testCases, ResourceForemanHostgroupEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanHostgroupEmptyResponseTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanHostEmptyResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanMediaEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaEmptyResponseTestCases(t)...)
 
@@ -524,6 +701,9 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanSubnetEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSubnetFreeIpEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, SearchDataSourcesEmptyResponseTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanComputeResourceEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanComputeResourceEmptyResponseTestCases(t)...)
@@ -533,6 +713,24 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanTemplateKindEmptyResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanUserEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanUserEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRoleEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanFilterEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanUsergroupEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanExternalUsergroupEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanAuthSourceLdapEmptyResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRealmEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRealmEmptyResponseTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -606,6 +804,9 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanArchitectureMockResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanOrganizationMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationMockResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanDomainMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanDomainMockResponseTestCases(t)...)
 
@@ -617,6 +818,8 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 	testCases = append(testCases, ResourceForemanHostgroupMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanHostgroupMockResponseTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanHostMockResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanMediaMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaMockResponseTestCases(t)...)
 
@@ -637,9 +840,30 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanSubnetMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSubnetFreeIpMockResponseTestCases(t)...)
+
+	testCases = append(testCases, SearchDataSourcesMockResponseTestCases(t)...)
 
 	testCases = append(testCases, DataSourceForemanTemplateKindMockResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanUserMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanUserMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRoleMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanFilterMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanUsergroupMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanExternalUsergroupMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanAuthSourceLdapMockResponseTestCases(t)...)
+
+	testCases = append(testCases, ResourceForemanRealmMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRealmMockResponseTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
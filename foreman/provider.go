@@ -1,9 +1,11 @@
 package foreman
 
 import (
+	"fmt"
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	logger "github.com/HanseMerkur/terraform-provider-utils/log"
@@ -23,6 +25,21 @@ const (
 	ClientUsernameEnv string = "FOREMAN_CLIENT_USERNAME"
 	// Environment variable to configure the client_password attribute
 	ClientPasswordEnv string = "FOREMAN_CLIENT_PASSWORD"
+	// Environment variable to configure the client_personal_access_token
+	// attribute
+	ClientPersonalAccessTokenEnv string = "FOREMAN_CLIENT_PERSONAL_ACCESS_TOKEN"
+	// Environment variable to configure the client_oauth_consumer_key
+	// attribute
+	ClientOAuthConsumerKeyEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_KEY"
+	// Environment variable to configure the client_oauth_consumer_secret
+	// attribute
+	ClientOAuthConsumerSecretEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET"
+	// Environment variable to configure the client_tls_ca_cert attribute
+	ClientTLSCACertEnv string = "FOREMAN_CLIENT_TLS_CA_CERT"
+	// Environment variable to configure the client_tls_client_cert attribute
+	ClientTLSClientCertEnv string = "FOREMAN_CLIENT_TLS_CLIENT_CERT"
+	// Environment variable to configure the client_tls_client_key attribute
+	ClientTLSClientKeyEnv string = "FOREMAN_CLIENT_TLS_CLIENT_KEY"
 )
 
 // Provider configuration default values
@@ -31,6 +48,11 @@ const (
 	DefaultProviderLogLevel string = "NONE"
 	// Default output log file if one is not provided
 	DefaultProviderLogFile string = "terraform-provider-foreman.log"
+	// Default number of retries of a failed API request
+	DefaultClientMaxRetries int = 3
+	// Default minimum and maximum wait time in seconds between retries
+	DefaultClientRetryWaitMin int = 1
+	DefaultClientRetryWaitMax int = 30
 )
 
 // Log file constants
@@ -121,6 +143,90 @@ func Provider() terraform.ResourceProvider {
 				Description: "Whether or not to verify the server's certificate. " +
 					"Defaults to `false`.",
 			},
+			"client_tls_ca_cert": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCACertEnv,
+					"",
+				),
+				Description: "A CA bundle used to verify the server's certificate in " +
+					"addition to the system's CAs. Either the PEM encoded certificates or " +
+					"the path to a file containing them. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_TLS_CA_CERT`. Defaults to `\"\"`.",
+			},
+			"client_tls_client_cert": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSClientCertEnv,
+					"",
+				),
+				Description: "A client certificate presented to the server for mutual " +
+					"TLS authentication, such as the certificate of a smart proxy. Either " +
+					"the PEM encoded certificate or the path to a file containing it. " +
+					"Requires `client_tls_client_key`. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_TLS_CLIENT_CERT`. Defaults to `\"\"`.",
+			},
+			"client_tls_client_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSClientKeyEnv,
+					"",
+				),
+				Description: "The private key of `client_tls_client_cert`. Either the " +
+					"PEM encoded key or the path to a file containing it. This can also be " +
+					"set through the environment variable `FOREMAN_CLIENT_TLS_CLIENT_KEY`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_tls_server_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name used to verify the server's certificate instead " +
+					"of `server_hostname`. Useful if Foreman is reached through an " +
+					"address its certificate is not issued for.",
+			},
+			"client_query_per_page": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      api.FOREMAN_QUERY_PER_PAGE,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The number of results to request per page when " +
+					"searching the Foreman API. The provider follows the pages of a " +
+					"search until all results have been retrieved. Defaults to `100`.",
+			},
+			"client_max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultClientMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Number of times to retry an API request when Foreman " +
+					"cannot be reached or responds with a temporary failure (429, 502, " +
+					"503, 504). Requests which are not idempotent, ie: creating an " +
+					"object or a power action, are only retried on 429 and 503. A " +
+					"value of `0` disables retries. Defaults to `3`.",
+			},
+			"client_retry_wait_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultClientRetryWaitMin,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Minimum time in seconds to wait before retrying a " +
+					"failed API request. The wait time grows exponentially with each " +
+					"retry. A `Retry-After` header sent by Foreman takes precedence. " +
+					"Defaults to `1`.",
+			},
+			"client_retry_wait_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultClientRetryWaitMax,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Maximum time in seconds to wait before retrying a " +
+					"failed API request. A longer `Retry-After` header sent by Foreman " +
+					"is capped at this value. Defaults to `30`.",
+			},
 
 			// -- client credentials --
 
@@ -146,6 +252,47 @@ func Provider() terraform.ResourceProvider {
 					"also be set through the environment variable `FOREMAN_CLIENT_PASSWORD`. " +
 					"Defaults to `\"\"`.",
 			},
+			"client_personal_access_token": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientPersonalAccessTokenEnv,
+					"",
+				),
+				Description: "A personal access token of the user given in " +
+					"`client_username`. If set, the token is used in place of " +
+					"`client_password`. This can also be set through the environment " +
+					"variable `FOREMAN_CLIENT_PERSONAL_ACCESS_TOKEN`. Defaults to `\"\"`.",
+			},
+			"client_oauth_consumer_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerKeyEnv,
+					"",
+				),
+				Description: "The OAuth consumer key configured in Foreman's settings. " +
+					"If set, requests are signed with OAuth 1.0a instead of using " +
+					"`client_password` or `client_personal_access_token`, and " +
+					"`client_username` is sent in the `FOREMAN-USER` header for " +
+					"Foreman's `oauth_map_users` setting. Requires " +
+					"`client_oauth_consumer_secret`. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_OAUTH_CONSUMER_KEY`. Defaults " +
+					"to `\"\"`.",
+			},
+			"client_oauth_consumer_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerSecretEnv,
+					"",
+				),
+				Description: "The OAuth consumer secret configured in Foreman's " +
+					"settings. This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET`. Defaults to `\"\"`.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -162,6 +309,7 @@ func Provider() terraform.ResourceProvider {
 			"foreman_image":                resourceForemanImage(),
 			"foreman_environment":          resourceForemanEnvironment(),
 			"foreman_location":             resourceForemanLocation(),
+			"foreman_organization":         resourceForemanOrganization(),
 			"foreman_parameter":            resourceForemanParameter(),
 			"foreman_global_parameter":     resourceForemanCommonParameter(),
 			"foreman_subnet":               resourceForemanSubnet(),
@@ -171,21 +319,36 @@ func Provider() terraform.ResourceProvider {
 			"foreman_computeprofile":       resourceForemanComputeProfile(),
 			"foreman_puppetclass":          resourceForemanPuppetClass(),
 			"foreman_smartclassparameter":  resourceForemanSmartClassParameter(),
+			"foreman_user":                 resourceForemanUser(),
+			"foreman_role":                 resourceForemanRole(),
+			"foreman_filter":               resourceForemanFilter(),
+			"foreman_usergroup":            resourceForemanUsergroup(),
+			"foreman_external_usergroup":   resourceForemanExternalUsergroup(),
+			"foreman_auth_source_ldap":     resourceForemanAuthSourceLdap(),
+			"foreman_realm":                resourceForemanRealm(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"foreman_architecture":         dataSourceForemanArchitecture(),
 			"foreman_domain":               dataSourceForemanDomain(),
+			"foreman_domains":              dataSourceForemanDomains(),
 			"foreman_environment":          dataSourceForemanEnvironment(),
 			"foreman_location":             dataSourceForemanLocation(),
+			"foreman_organization":         dataSourceForemanOrganization(),
 			"foreman_hostgroup":            dataSourceForemanHostgroup(),
+			"foreman_hostgroups":           dataSourceForemanHostgroups(),
+			"foreman_host":                 dataSourceForemanHost(),
+			"foreman_hosts":                dataSourceForemanHosts(),
 			"foreman_media":                dataSourceForemanMedia(),
 			"foreman_model":                dataSourceForemanModel(),
 			"foreman_operatingsystem":      dataSourceForemanOperatingSystem(),
+			"foreman_operatingsystems":     dataSourceForemanOperatingSystems(),
 			"foreman_partitiontable":       dataSourceForemanPartitionTable(),
 			"foreman_provisioningtemplate": dataSourceForemanProvisioningTemplate(),
 			"foreman_smartproxy":           dataSourceForemanSmartProxy(),
 			"foreman_subnet":               dataSourceForemanSubnet(),
+			"foreman_subnets":              dataSourceForemanSubnets(),
+			"foreman_subnet_free_ip":       dataSourceForemanSubnetFreeIp(),
 			"foreman_templatekind":         dataSourceForemanTemplateKind(),
 			"foreman_computeprofile":       dataSourceForemanComputeProfile(),
 			"foreman_computeresource":      dataSourceForemanComputeResource(),
@@ -195,6 +358,10 @@ func Provider() terraform.ResourceProvider {
 			"foreman_defaulttemplate":      dataSourceForemanDefaultTemplate(),
 			"foreman_puppetclass":          dataSourceForemanPuppetClass(),
 			"foreman_smartclassparameter":  dataSourceForemanSmartClassParameter(),
+			"foreman_user":                 dataSourceForemanUser(),
+			"foreman_role":                 dataSourceForemanRole(),
+			"foreman_permission":           dataSourceForemanPermission(),
+			"foreman_realm":                dataSourceForemanRealm(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -265,13 +432,38 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 			},
 		},
 		// -- client configuration --
-		ClientTLSInsecure: d.Get("client_tls_insecure").(bool),
+		ClientTLSInsecure:   d.Get("client_tls_insecure").(bool),
+		ClientTLSCACert:     d.Get("client_tls_ca_cert").(string),
+		ClientTLSClientCert: d.Get("client_tls_client_cert").(string),
+		ClientTLSClientKey:  d.Get("client_tls_client_key").(string),
+		ClientTLSServerName: d.Get("client_tls_server_name").(string),
+		ClientQueryPerPage:  d.Get("client_query_per_page").(int),
+		ClientMaxRetries:    d.Get("client_max_retries").(int),
+		ClientRetryWaitMin:  time.Duration(d.Get("client_retry_wait_min").(int)) * time.Second,
+		ClientRetryWaitMax:  time.Duration(d.Get("client_retry_wait_max").(int)) * time.Second,
 		ClientCredentials: api.ClientCredentials{
-			Username: d.Get("client_username").(string),
-			Password: d.Get("client_password").(string),
+			Username:            d.Get("client_username").(string),
+			Password:            d.Get("client_password").(string),
+			PersonalAccessToken: d.Get("client_personal_access_token").(string),
+			OAuthConsumerKey:    d.Get("client_oauth_consumer_key").(string),
+			OAuthConsumerSecret: d.Get("client_oauth_consumer_secret").(string),
 		},
 	}
 
+	cred := config.ClientCredentials
+	if (cred.OAuthConsumerKey == "") != (cred.OAuthConsumerSecret == "") {
+		return nil, fmt.Errorf(
+			"Both 'client_oauth_consumer_key' and 'client_oauth_consumer_secret' " +
+				"must be set to authenticate with OAuth",
+		)
+	}
+	if cred.PersonalAccessToken != "" && cred.Username == "" {
+		return nil, fmt.Errorf(
+			"'client_username' must be set to authenticate with " +
+				"'client_personal_access_token'",
+		)
+	}
+
 	return config.Client()
 }
 
@@ -147,7 +147,7 @@ func resourceForemanArchitectureRead(d *schema.ResourceData, meta interface{}) e
 
 	readArch, readErr := client.ReadArchitecture(a.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanArchitecture: [%+v]", readArch)
@@ -275,7 +275,7 @@ func ResourceForemanArchitectureRequestDataTestCases(t *testing.T) []TestCaseReq
 
 	rd := MockForemanArchitectureResourceData(s)
 	obj = *buildForemanArchitecture(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("architecture", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
@@ -0,0 +1,424 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanAuthSourceLdap() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanAuthSourceLdapCreate,
+		Read:   resourceForemanAuthSourceLdapRead,
+		Update: resourceForemanAuthSourceLdapUpdate,
+		Delete: resourceForemanAuthSourceLdapDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s An LDAP authentication source. Users authenticate against "+
+						"the LDAP server and external usergroups are synchronized "+
+						"with its groups.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the authentication source. "+
+						"%s \"Company LDAP\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"host": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Hostname of the LDAP server. "+
+						"%s \"ldap.company.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      389,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "Port of the LDAP server. Defaults to `389`.",
+			},
+
+			"tls": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not to use LDAPS to connect to the LDAP " +
+					"server. Defaults to `false`.",
+			},
+
+			"server_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "posix",
+				ValidateFunc: validation.StringInSlice([]string{
+					"free_ipa",
+					"active_directory",
+					"posix",
+				}, false),
+				Description: "Type of the LDAP server. Valid values are " +
+					"`free_ipa`, `active_directory` and `posix`. Defaults to " +
+					"`posix`.",
+			},
+
+			"account": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Account used to bind to the LDAP server. Anonymous binds are "+
+						"used if not set. The account may contain a $login variable, "+
+						"which is replaced by the login of the authenticating user. "+
+						"%s \"uid=foreman,ou=services,dc=company,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"account_password": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Password of the account. Foreman never returns the " +
+					"password, so changes made outside of terraform are not detected.",
+			},
+
+			"base_dn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN of the users. "+
+						"%s \"ou=people,dc=company,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"ldap_filter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP filter applied to the lookup of users. "+
+						"%s \"(memberOf=cn=foreman,ou=groups,dc=company,dc=com)\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_login": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the user's login. Required for on the "+
+						"fly registration. "+
+						"%s \"uid\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_firstname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the user's first name. "+
+						"%s \"givenName\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_lastname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the user's last name. "+
+						"%s \"sn\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_mail": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the user's e-mail address. "+
+						"%s \"mail\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_photo": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the user's photo. "+
+						"%s \"jpegPhoto\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"onthefly_register": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not users are created in Foreman on their " +
+					"first login. Defaults to `false`.",
+			},
+
+			"groups_base": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN of the groups. "+
+						"%s \"ou=groups,dc=company,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"usergroup_sync": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether or not the members of external usergroups " +
+					"are kept in sync with the LDAP groups. Defaults to `true`.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"location_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the authentication source is " +
+					"assigned to.",
+			},
+
+			"organization_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the authentication source is " +
+					"assigned to.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanAuthSourceLdap constructs a ForemanAuthSourceLdap reference
+// from a resource data reference.  The struct's  members are populated from
+// the data populated in the resource data.  Missing members will be left to
+// the zero value for that member's type.
+func buildForemanAuthSourceLdap(d *schema.ResourceData) *api.ForemanAuthSourceLdap {
+	log.Tracef("resource_foreman_auth_source_ldap.go#buildForemanAuthSourceLdap")
+
+	authSource := api.ForemanAuthSourceLdap{}
+
+	obj := buildForemanObject(d)
+	authSource.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("host"); ok {
+		authSource.Host = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("port"); ok {
+		authSource.Port = attr.(int)
+	}
+
+	authSource.Tls = d.Get("tls").(bool)
+
+	if attr, ok = d.GetOk("server_type"); ok {
+		authSource.ServerType = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("account"); ok {
+		authSource.Account = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("account_password"); ok {
+		authSource.AccountPassword = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("base_dn"); ok {
+		authSource.BaseDn = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("ldap_filter"); ok {
+		authSource.LdapFilter = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("attr_login"); ok {
+		authSource.AttrLogin = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("attr_firstname"); ok {
+		authSource.AttrFirstname = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("attr_lastname"); ok {
+		authSource.AttrLastname = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("attr_mail"); ok {
+		authSource.AttrMail = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("attr_photo"); ok {
+		authSource.AttrPhoto = attr.(string)
+	}
+
+	authSource.OntheflyRegister = d.Get("onthefly_register").(bool)
+
+	if attr, ok = d.GetOk("groups_base"); ok {
+		authSource.GroupsBase = attr.(string)
+	}
+
+	authSource.UsergroupSync = d.Get("usergroup_sync").(bool)
+
+	if attr, ok = d.GetOk("location_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		authSource.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("organization_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		authSource.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	return &authSource
+}
+
+// setResourceDataFromForemanAuthSourceLdap sets a ResourceData's attributes
+// from the attributes of the supplied ForemanAuthSourceLdap reference
+func setResourceDataFromForemanAuthSourceLdap(d *schema.ResourceData, fa *api.ForemanAuthSourceLdap) {
+	log.Tracef("resource_foreman_auth_source_ldap.go#setResourceDataFromForemanAuthSourceLdap")
+
+	// NOTE(ALL): the account password is never returned by Foreman, keep the
+	//   value from the configuration
+
+	d.SetId(strconv.Itoa(fa.Id))
+	d.Set("name", fa.Name)
+	d.Set("host", fa.Host)
+	d.Set("port", fa.Port)
+	d.Set("tls", fa.Tls)
+	d.Set("server_type", fa.ServerType)
+	d.Set("account", fa.Account)
+	d.Set("base_dn", fa.BaseDn)
+	d.Set("ldap_filter", fa.LdapFilter)
+	d.Set("attr_login", fa.AttrLogin)
+	d.Set("attr_firstname", fa.AttrFirstname)
+	d.Set("attr_lastname", fa.AttrLastname)
+	d.Set("attr_mail", fa.AttrMail)
+	d.Set("attr_photo", fa.AttrPhoto)
+	d.Set("onthefly_register", fa.OntheflyRegister)
+	d.Set("groups_base", fa.GroupsBase)
+	d.Set("usergroup_sync", fa.UsergroupSync)
+	d.Set("location_ids", fa.LocationIds)
+	d.Set("organization_ids", fa.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanAuthSourceLdapCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Create")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	createdAuthSourceLdap, createErr := client.CreateAuthSourceLdap(a)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanAuthSourceLdap: [%+v]", createdAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, createdAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	readAuthSourceLdap, readErr := client.ReadAuthSourceLdap(a.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanAuthSourceLdap: [%+v]", readAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, readAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Update")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	// NOTE(ALL): only send the account password if it changed
+	if !d.HasChange("account_password") {
+		a.AccountPassword = ""
+	}
+
+	updatedAuthSourceLdap, updateErr := client.UpdateAuthSourceLdap(a)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanAuthSourceLdap: [%+v]", updatedAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, updatedAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Delete")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteAuthSourceLdap(a.Id)
+}
@@ -0,0 +1,452 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const AuthSourceLdapsURI = api.FOREMAN_API_URL_PREFIX + "/auth_source_ldaps"
+const AuthSourceLdapsTestDataPath = "testdata/1.11/auth_source_ldaps"
+
+// Given a ForemanAuthSourceLdap, create a mock instance state reference
+func ForemanAuthSourceLdapToInstanceState(obj api.ForemanAuthSourceLdap) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanAuthSourceLdap
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["host"] = obj.Host
+	attr["port"] = strconv.Itoa(obj.Port)
+	attr["tls"] = strconv.FormatBool(obj.Tls)
+	attr["server_type"] = obj.ServerType
+	attr["account"] = obj.Account
+	attr["base_dn"] = obj.BaseDn
+	attr["ldap_filter"] = obj.LdapFilter
+	attr["attr_login"] = obj.AttrLogin
+	attr["attr_firstname"] = obj.AttrFirstname
+	attr["attr_lastname"] = obj.AttrLastname
+	attr["attr_mail"] = obj.AttrMail
+	attr["attr_photo"] = obj.AttrPhoto
+	attr["onthefly_register"] = strconv.FormatBool(obj.OntheflyRegister)
+	attr["groups_base"] = obj.GroupsBase
+	attr["usergroup_sync"] = strconv.FormatBool(obj.UsergroupSync)
+	attr["location_ids.#"] = strconv.Itoa(len(obj.LocationIds))
+	for idx, val := range obj.LocationIds {
+		key := fmt.Sprintf("location_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["organization_ids.#"] = strconv.Itoa(len(obj.OrganizationIds))
+	for idx, val := range obj.OrganizationIds {
+		key := fmt.Sprintf("organization_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanAuthSourceLdap resource, create a
+// mock ResourceData reference.
+func MockForemanAuthSourceLdapResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanAuthSourceLdap()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an LDAP authentication source
+// ResourceData reference
+func MockForemanAuthSourceLdapResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanAuthSourceLdap
+	ParseJSONFile(t, path, &obj)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+	return MockForemanAuthSourceLdapResourceData(s)
+}
+
+// Creates a random ForemanAuthSourceLdap struct
+func RandForemanAuthSourceLdap() api.ForemanAuthSourceLdap {
+	obj := api.ForemanAuthSourceLdap{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Host = tfrand.String(10, tfrand.Lower) + ".company.com"
+	obj.Port = rand.Intn(65535) + 1
+	obj.Tls = rand.Intn(2) > 0
+	obj.ServerType = "posix"
+	obj.Account = tfrand.String(10, tfrand.Lower)
+	obj.BaseDn = tfrand.String(10, tfrand.Lower)
+	obj.LdapFilter = tfrand.String(10, tfrand.Lower)
+	obj.AttrLogin = tfrand.String(10, tfrand.Lower)
+	obj.AttrFirstname = tfrand.String(10, tfrand.Lower)
+	obj.AttrLastname = tfrand.String(10, tfrand.Lower)
+	obj.AttrMail = tfrand.String(10, tfrand.Lower)
+	obj.AttrPhoto = tfrand.String(10, tfrand.Lower)
+	obj.OntheflyRegister = rand.Intn(2) > 0
+	obj.GroupsBase = tfrand.String(10, tfrand.Lower)
+	obj.UsergroupSync = rand.Intn(2) > 0
+	obj.LocationIds = tfrand.IntArrayUnique(5)
+	obj.OrganizationIds = tfrand.IntArrayUnique(5)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanAuthSourceLdap resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanAuthSourceLdapResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanAuthSourceLdap()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"location_ids", "organization_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestAuthSourceLdapUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanAuthSourceLdap
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanAuthSourceLdap UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanAuthSourceLdap UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanAuthSourceLdap
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanAuthSourceLdap
+func TestBuildForemanAuthSourceLdap(t *testing.T) {
+
+	expectedObj := RandForemanAuthSourceLdap()
+	expectedState := ForemanAuthSourceLdapToInstanceState(expectedObj)
+	expectedResourceData := MockForemanAuthSourceLdapResourceData(expectedState)
+
+	actualObj := *buildForemanAuthSourceLdap(expectedResourceData)
+
+	actualState := ForemanAuthSourceLdapToInstanceState(actualObj)
+	actualResourceData := MockForemanAuthSourceLdapResourceData(actualState)
+
+	ForemanAuthSourceLdapResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanAuthSourceLdap
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanAuthSourceLdap_Value(t *testing.T) {
+
+	expectedObj := RandForemanAuthSourceLdap()
+	expectedState := ForemanAuthSourceLdapToInstanceState(expectedObj)
+	expectedResourceData := MockForemanAuthSourceLdapResourceData(expectedState)
+
+	actualObj := api.ForemanAuthSourceLdap{}
+	actualState := ForemanAuthSourceLdapToInstanceState(actualObj)
+	actualResourceData := MockForemanAuthSourceLdapResourceData(actualState)
+
+	setResourceDataFromForemanAuthSourceLdap(actualResourceData, &expectedObj)
+
+	ForemanAuthSourceLdapResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanAuthSourceLdapCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanAuthSourceLdap{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+	authSourceLdapsURIById := AuthSourceLdapsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapCreate",
+				crudFunc:     resourceForemanAuthSourceLdapCreate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedURI:    AuthSourceLdapsURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapRead",
+				crudFunc:     resourceForemanAuthSourceLdapRead,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedURI:    authSourceLdapsURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapUpdate",
+				crudFunc:     resourceForemanAuthSourceLdapUpdate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedURI:    authSourceLdapsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapDelete",
+				crudFunc:     resourceForemanAuthSourceLdapDelete,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedURI:    authSourceLdapsURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanAuthSourceLdapRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanAuthSourceLdap{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapRead",
+			crudFunc:     resourceForemanAuthSourceLdapRead,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapDelete",
+			crudFunc:     resourceForemanAuthSourceLdapDelete,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanAuthSourceLdapRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanAuthSourceLdap{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+
+	rd := MockForemanAuthSourceLdapResourceData(s)
+	obj = *buildForemanAuthSourceLdap(rd)
+	reqData, _ := api.WrapJson("auth_source_ldap", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapCreate",
+				crudFunc:     resourceForemanAuthSourceLdapCreate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapUpdate",
+				crudFunc:     resourceForemanAuthSourceLdapUpdate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanAuthSourceLdapStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanAuthSourceLdap{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapCreate",
+			crudFunc:     resourceForemanAuthSourceLdapCreate,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapRead",
+			crudFunc:     resourceForemanAuthSourceLdapRead,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapUpdate",
+			crudFunc:     resourceForemanAuthSourceLdapUpdate,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapDelete",
+			crudFunc:     resourceForemanAuthSourceLdapDelete,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanAuthSourceLdapEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanAuthSourceLdap{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapCreate",
+			crudFunc:     resourceForemanAuthSourceLdapCreate,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapRead",
+			crudFunc:     resourceForemanAuthSourceLdapRead,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanAuthSourceLdapUpdate",
+			crudFunc:     resourceForemanAuthSourceLdapUpdate,
+			resourceData: MockForemanAuthSourceLdapResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanAuthSourceLdapMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanAuthSourceLdap()
+	s := ForemanAuthSourceLdapToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapCreate",
+				crudFunc:     resourceForemanAuthSourceLdapCreate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			responseFile: AuthSourceLdapsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLdapResourceDataFromFile(
+				t,
+				AuthSourceLdapsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanAuthSourceLdapResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapRead",
+				crudFunc:     resourceForemanAuthSourceLdapRead,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			responseFile: AuthSourceLdapsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLdapResourceDataFromFile(
+				t,
+				AuthSourceLdapsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanAuthSourceLdapResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLdapUpdate",
+				crudFunc:     resourceForemanAuthSourceLdapUpdate,
+				resourceData: MockForemanAuthSourceLdapResourceData(s),
+			},
+			responseFile: AuthSourceLdapsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLdapResourceDataFromFile(
+				t,
+				AuthSourceLdapsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanAuthSourceLdapResourceDataCompare,
+		},
+	}
+
+}
@@ -3,12 +3,14 @@ package foreman
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceForemanCommonParameter() *schema.Resource {
@@ -23,6 +25,8 @@ func resourceForemanCommonParameter() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffForemanParameter,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -40,8 +44,29 @@ func resourceForemanCommonParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressEquivalentForemanParameterValue,
+				Description: "Value of the parameter.  It must be valid for the " +
+					"parameter_type, ie: \"true\" for a \"boolean\".  The value is " +
+					"redacted from plan output since it may be hidden.",
+			},
+			"parameter_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "string",
+				ValidateFunc: validation.StringInSlice(foremanParameterTypes, false),
+				Description: fmt.Sprintf(
+					"Type Foreman parses the value as. Valid values: %s.",
+					strings.Join(foremanParameterTypes, ", "),
+				),
+			},
+			"hidden_value": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether Foreman hides the value of the parameter.",
 			},
 		},
 	}
@@ -72,6 +97,10 @@ func buildForemanCommonParameter(d *schema.ResourceData) *api.ForemanCommonParam
 	if attr, ok = d.GetOk("value"); ok {
 		common_parameter.Value = attr.(string)
 	}
+	if attr, ok = d.GetOk("parameter_type"); ok {
+		common_parameter.ParameterType = attr.(string)
+	}
+	common_parameter.HiddenValue = d.Get("hidden_value").(bool)
 	return &common_parameter
 }
 
@@ -82,7 +111,15 @@ func setResourceDataFromForemanCommonParameter(d *schema.ResourceData, fd *api.F
 
 	d.SetId(strconv.Itoa(fd.Id))
 	d.Set("name", fd.Name)
-	d.Set("value", fd.Value)
+	// NOTE(ALL): See the note in setForemanKVParameters().  The known value of
+	//   a hidden parameter is kept.
+	if !fd.HiddenValue || d.Get("value").(string) == "" {
+		d.Set("value", fd.Value)
+	}
+	if fd.ParameterType != "" {
+		d.Set("parameter_type", fd.ParameterType)
+	}
+	d.Set("hidden_value", fd.HiddenValue)
 }
 
 // -----------------------------------------------------------------------------
@@ -119,7 +156,7 @@ func resourceForemanCommonParameterRead(d *schema.ResourceData, meta interface{}
 
 	readCommonParameter, readErr := client.ReadCommonParameter(common_parameter, common_parameter.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanCommonParameter: [%+v]", readCommonParameter)
@@ -245,7 +245,7 @@ func resourceForemanComputeAttributesRead(d *schema.ResourceData, meta interface
 
 	readTemplate, readErr := client.ReadComputeAttributes(t.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanComputeAttributes: [%+v]", readTemplate)
@@ -38,7 +38,8 @@ func resourceForemanComputeProfile() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				Description: fmt.Sprintf(
-					"Name of the ComputeProfile",
+					"Name of the ComputeProfile. "+
+						"%s \"1-Small\"",
 					autodoc.MetaExample,
 				),
 			},
@@ -115,7 +116,7 @@ func resourceForemanComputeProfileRead(d *schema.ResourceData, meta interface{})
 
 	readComputeProfile, readErr := client.ReadComputeProfile(e.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanComputeProfile: [%+v]", readComputeProfile)
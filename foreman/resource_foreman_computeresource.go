@@ -183,7 +183,7 @@ func resourceForemanComputeResourceRead(d *schema.ResourceData, meta interface{}
 
 	readComputeResource, readErr := client.ReadComputeResource(computeresource.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanComputeResource: [%+v]", readComputeResource)
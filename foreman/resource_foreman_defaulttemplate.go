@@ -135,7 +135,7 @@ func resourceForemanDefaultTemplateRead(d *schema.ResourceData, meta interface{}
 
 	readDefaultTemplate, readErr := client.ReadDefaultTemplate(defaultTemplate, defaultTemplate.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanDefaultTemplate: [%+v]", readDefaultTemplate)
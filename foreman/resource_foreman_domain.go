@@ -9,6 +9,7 @@ import (
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceForemanDomain() *schema.Resource {
@@ -23,6 +24,8 @@ func resourceForemanDomain() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffForemanParameters,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -53,12 +56,32 @@ func resourceForemanDomain() *schema.Resource {
 			},
 
 			"parameters": &schema.Schema{
-				Type:     schema.TypeMap,
-				ForceNew: false,
-				Optional: true,
+				Type:          schema.TypeMap,
+				ForceNew:      false,
+				Optional:      true,
+				ConflictsWith: []string{"parameter"},
 				Description: "A map of parameters that will be saved as domain parameters " +
 					"in the domain config.",
 			},
+			"parameter": foremanParameterSchema("domain"),
+
+			// -- Foreign Key Relationships --
+
+			"dns_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the smart proxy providing DNS for this domain. " +
+					"Required for Foreman to orchestrate DNS records of hosts.",
+			},
+
+			"organization_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the organization the domain belongs to.",
+			},
 		},
 	}
 }
@@ -86,16 +109,16 @@ func buildForemanDomain(d *schema.ResourceData) *api.ForemanDomain {
 		domain.Fullname = attr.(string)
 	}
 
-	if attr, ok = d.GetOk("parameters"); ok {
-		domainTags := d.Get("parameters").(map[string]interface{})
-		for key, value := range domainTags {
-			domain.DomainParameters = append(domain.DomainParameters, api.ForemanKVParameter{
-				Name:  key,
-				Value: value.(string),
-			})
-		}
+	if attr, ok = d.GetOk("dns_id"); ok {
+		domain.DnsId = attr.(int)
 	}
 
+	if attr, ok = d.GetOk("organization_id"); ok {
+		domain.OrganizationId = attr.(int)
+	}
+
+	domain.DomainParameters = buildForemanKVParameters(d)
+
 	return &domain
 }
 
@@ -107,7 +130,9 @@ func setResourceDataFromForemanDomain(d *schema.ResourceData, fd *api.ForemanDom
 	d.SetId(strconv.Itoa(fd.Id))
 	d.Set("name", fd.Name)
 	d.Set("fullname", fd.Fullname)
-	d.Set("parameters", fd.DomainParameters)
+	d.Set("dns_id", fd.DnsId)
+	setForemanKVParameters(d, fd.DomainParameters)
+	d.Set("organization_id", fd.OrganizationId)
 }
 
 // -----------------------------------------------------------------------------
@@ -144,7 +169,7 @@ func resourceForemanDomainRead(d *schema.ResourceData, meta interface{}) error {
 
 	readDomain, readErr := client.ReadDomain(domain.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanDomain: [%+v]", readDomain)
@@ -161,6 +186,19 @@ func resourceForemanDomainUpdate(d *schema.ResourceData, meta interface{}) error
 
 	log.Debugf("ForemanDomain: [%+v]", do)
 
+	// NOTE(ALL): Parameters are only sent to Foreman when they changed.  The
+	//   IDs of the existing parameters are read from Foreman to update and
+	//   remove them.
+	if d.HasChange("parameters") || d.HasChange("parameter") {
+		currentDomain, readErr := client.ReadDomain(do.Id)
+		if readErr != nil {
+			return readErr
+		}
+		do.DomainParameters = reconcileForemanKVParameters(currentDomain.DomainParameters, do.DomainParameters)
+	} else {
+		do.DomainParameters = nil
+	}
+
 	updatedDomain, updateErr := client.UpdateDomain(do, do.Id)
 	if updateErr != nil {
 		return updateErr
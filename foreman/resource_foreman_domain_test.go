@@ -30,6 +30,7 @@ func ForemanDomainToInstanceState(obj api.ForemanDomain) *terraform.InstanceStat
 	attr := map[string]string{}
 	attr["name"] = obj.Name
 	attr["fullname"] = obj.Fullname
+	attr["dns_id"] = strconv.Itoa(obj.DnsId)
 	state.Attributes = attr
 	return &state
 }
@@ -58,6 +59,7 @@ func RandForemanDomain() api.ForemanDomain {
 	obj.ForemanObject = fo
 
 	obj.Fullname = tfrand.String(20, tfrand.Lower+".")
+	obj.DnsId = rand.Intn(100)
 
 	return obj
 }
@@ -117,7 +117,7 @@ func resourceForemanEnvironmentRead(d *schema.ResourceData, meta interface{}) er
 
 	readEnvironment, readErr := client.ReadEnvironment(e.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanEnvironment: [%+v]", readEnvironment)
@@ -0,0 +1,219 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanExternalUsergroup() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanExternalUsergroupCreate,
+		Read:   resourceForemanExternalUsergroupRead,
+		Update: resourceForemanExternalUsergroupUpdate,
+		Delete: resourceForemanExternalUsergroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceForemanExternalUsergroupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Maps a group of an authentication source, ie: an LDAP "+
+						"group, to a usergroup. Foreman keeps the members of the "+
+						"usergroup in sync with the members of the external group. "+
+						"Import with an ID in the form of \"<usergroup_id>/<id>\".",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the group in the authentication source. "+
+						"%s \"cn=engineering\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			// -- Foreign Key Relationships --
+
+			"usergroup_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the usergroup the external group is mapped to.",
+			},
+
+			"auth_source_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the authentication source the external group is " +
+					"defined in.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanExternalUsergroup constructs a ForemanExternalUsergroup
+// reference from a resource data reference.  The struct's  members are
+// populated from the data populated in the resource data.  Missing members
+// will be left to the zero value for that member's type.
+func buildForemanExternalUsergroup(d *schema.ResourceData) *api.ForemanExternalUsergroup {
+	log.Tracef("resource_foreman_external_usergroup.go#buildForemanExternalUsergroup")
+
+	externalUsergroup := api.ForemanExternalUsergroup{}
+
+	obj := buildForemanObject(d)
+	externalUsergroup.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("usergroup_id"); ok {
+		externalUsergroup.UsergroupId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("auth_source_id"); ok {
+		externalUsergroup.AuthSourceId = attr.(int)
+	}
+
+	return &externalUsergroup
+}
+
+// setResourceDataFromForemanExternalUsergroup sets a ResourceData's
+// attributes from the attributes of the supplied ForemanExternalUsergroup
+// reference
+func setResourceDataFromForemanExternalUsergroup(d *schema.ResourceData, fe *api.ForemanExternalUsergroup) {
+	log.Tracef("resource_foreman_external_usergroup.go#setResourceDataFromForemanExternalUsergroup")
+
+	d.SetId(strconv.Itoa(fe.Id))
+	d.Set("name", fe.Name)
+	d.Set("usergroup_id", fe.UsergroupId)
+	d.Set("auth_source_id", fe.AuthSourceId)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanExternalUsergroupCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_external_usergroup.go#Create")
+
+	client := meta.(*api.Client)
+	e := buildForemanExternalUsergroup(d)
+
+	log.Debugf("ForemanExternalUsergroup: [%+v]", e)
+
+	createdExternalUsergroup, createErr := client.CreateExternalUsergroup(e)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanExternalUsergroup: [%+v]", createdExternalUsergroup)
+
+	setResourceDataFromForemanExternalUsergroup(d, createdExternalUsergroup)
+
+	return nil
+}
+
+func resourceForemanExternalUsergroupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_external_usergroup.go#Read")
+
+	client := meta.(*api.Client)
+	e := buildForemanExternalUsergroup(d)
+
+	log.Debugf("ForemanExternalUsergroup: [%+v]", e)
+
+	readExternalUsergroup, readErr := client.ReadExternalUsergroup(e.UsergroupId, e.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanExternalUsergroup: [%+v]", readExternalUsergroup)
+
+	setResourceDataFromForemanExternalUsergroup(d, readExternalUsergroup)
+
+	return nil
+}
+
+func resourceForemanExternalUsergroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_external_usergroup.go#Update")
+
+	client := meta.(*api.Client)
+	e := buildForemanExternalUsergroup(d)
+
+	log.Debugf("ForemanExternalUsergroup: [%+v]", e)
+
+	updatedExternalUsergroup, updateErr := client.UpdateExternalUsergroup(e)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanExternalUsergroup: [%+v]", updatedExternalUsergroup)
+
+	setResourceDataFromForemanExternalUsergroup(d, updatedExternalUsergroup)
+
+	return nil
+}
+
+func resourceForemanExternalUsergroupDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_external_usergroup.go#Delete")
+
+	client := meta.(*api.Client)
+	e := buildForemanExternalUsergroup(d)
+
+	log.Debugf("ForemanExternalUsergroup: [%+v]", e)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteExternalUsergroup(e.UsergroupId, e.Id)
+}
+
+// resourceForemanExternalUsergroupImport imports an external usergroup by an
+// ID in the form of "<usergroup_id>/<id>".  External usergroups are nested
+// under their usergroup in the API, the usergroup's ID is required to read
+// them.
+func resourceForemanExternalUsergroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_external_usergroup.go#Import")
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf(
+			"Unexpected ID [%s] for the external usergroup import. Expected "+
+				"an ID in the form of \"<usergroup_id>/<id>\"",
+			d.Id(),
+		)
+	}
+	usergroupId, usergroupIdErr := strconv.Atoi(parts[0])
+	if usergroupIdErr != nil {
+		return nil, fmt.Errorf("Invalid usergroup ID [%s]: %s", parts[0], usergroupIdErr)
+	}
+	if _, idErr := strconv.Atoi(parts[1]); idErr != nil {
+		return nil, fmt.Errorf("Invalid external usergroup ID [%s]: %s", parts[1], idErr)
+	}
+
+	d.SetId(parts[1])
+	d.Set("usergroup_id", usergroupId)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,438 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const ExternalUsergroupsURI = api.FOREMAN_API_URL_PREFIX + "/usergroups/%d/external_usergroups"
+const ExternalUsergroupsTestDataPath = "testdata/1.11/external_usergroups"
+
+// Given a ForemanExternalUsergroup, create a mock instance state reference
+func ForemanExternalUsergroupToInstanceState(obj api.ForemanExternalUsergroup) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanExternalUsergroup
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["usergroup_id"] = strconv.Itoa(obj.UsergroupId)
+	attr["auth_source_id"] = strconv.Itoa(obj.AuthSourceId)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanExternalUsergroup resource, create a
+// mock ResourceData reference.
+func MockForemanExternalUsergroupResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanExternalUsergroup()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an external usergroup
+// ResourceData reference
+func MockForemanExternalUsergroupResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanExternalUsergroup
+	ParseJSONFile(t, path, &obj)
+	s := ForemanExternalUsergroupToInstanceState(obj)
+	return MockForemanExternalUsergroupResourceData(s)
+}
+
+// Creates a random ForemanExternalUsergroup struct
+func RandForemanExternalUsergroup() api.ForemanExternalUsergroup {
+	obj := api.ForemanExternalUsergroup{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.UsergroupId = rand.Intn(100) + 1
+	obj.AuthSourceId = rand.Intn(100) + 1
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanExternalUsergroup resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanExternalUsergroupResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanExternalUsergroup()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestExternalUsergroupUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanExternalUsergroup
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanExternalUsergroup UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanExternalUsergroup UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanExternalUsergroup
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanExternalUsergroup
+func TestBuildForemanExternalUsergroup(t *testing.T) {
+
+	expectedObj := RandForemanExternalUsergroup()
+	expectedState := ForemanExternalUsergroupToInstanceState(expectedObj)
+	expectedResourceData := MockForemanExternalUsergroupResourceData(expectedState)
+
+	actualObj := *buildForemanExternalUsergroup(expectedResourceData)
+
+	actualState := ForemanExternalUsergroupToInstanceState(actualObj)
+	actualResourceData := MockForemanExternalUsergroupResourceData(actualState)
+
+	ForemanExternalUsergroupResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanExternalUsergroup
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanExternalUsergroup_Value(t *testing.T) {
+
+	expectedObj := RandForemanExternalUsergroup()
+	expectedState := ForemanExternalUsergroupToInstanceState(expectedObj)
+	expectedResourceData := MockForemanExternalUsergroupResourceData(expectedState)
+
+	actualObj := api.ForemanExternalUsergroup{}
+	actualState := ForemanExternalUsergroupToInstanceState(actualObj)
+	actualResourceData := MockForemanExternalUsergroupResourceData(actualState)
+
+	setResourceDataFromForemanExternalUsergroup(actualResourceData, &expectedObj)
+
+	ForemanExternalUsergroupResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanExternalUsergroupImport
+// -----------------------------------------------------------------------------
+
+// Ensures the import splits the ID into the usergroup ID and the ID and
+// rejects malformed IDs
+func TestResourceForemanExternalUsergroupImport(t *testing.T) {
+
+	rd := MockForemanExternalUsergroupResourceData(&terraform.InstanceState{ID: "12/34"})
+	results, importErr := resourceForemanExternalUsergroupImport(rd, nil)
+	if importErr != nil {
+		t.Fatalf(
+			"resourceForemanExternalUsergroupImport returned an error for a "+
+				"valid ID. Error: [%s]",
+			importErr,
+		)
+	}
+	if len(results) != 1 || results[0].Id() != "34" || results[0].Get("usergroup_id").(int) != 12 {
+		t.Errorf(
+			"resourceForemanExternalUsergroupImport did not set the ID and "+
+				"usergroup ID. Expected [34] and [12], got [%s] and [%v]",
+			rd.Id(),
+			rd.Get("usergroup_id"),
+		)
+	}
+
+	for _, id := range []string{"34", "12/34/56", "a/34", "12/b"} {
+		rd := MockForemanExternalUsergroupResourceData(&terraform.InstanceState{ID: id})
+		if _, importErr := resourceForemanExternalUsergroupImport(rd, nil); importErr == nil {
+			t.Errorf(
+				"resourceForemanExternalUsergroupImport did not return an error "+
+					"for the invalid ID [%s]",
+				id,
+			)
+		}
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanExternalUsergroupCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanExternalUsergroup{}
+	obj.Id = rand.Intn(100)
+	obj.UsergroupId = rand.Intn(100) + 1
+	s := ForemanExternalUsergroupToInstanceState(obj)
+	externalUsergroupsURI := fmt.Sprintf(ExternalUsergroupsURI, obj.UsergroupId)
+	externalUsergroupsURIById := externalUsergroupsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupCreate",
+				crudFunc:     resourceForemanExternalUsergroupCreate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedURI:    externalUsergroupsURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupRead",
+				crudFunc:     resourceForemanExternalUsergroupRead,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedURI:    externalUsergroupsURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupUpdate",
+				crudFunc:     resourceForemanExternalUsergroupUpdate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedURI:    externalUsergroupsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupDelete",
+				crudFunc:     resourceForemanExternalUsergroupDelete,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedURI:    externalUsergroupsURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanExternalUsergroupRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanExternalUsergroup{}
+	obj.Id = rand.Intn(100)
+	obj.UsergroupId = rand.Intn(100) + 1
+	s := ForemanExternalUsergroupToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupRead",
+			crudFunc:     resourceForemanExternalUsergroupRead,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupDelete",
+			crudFunc:     resourceForemanExternalUsergroupDelete,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanExternalUsergroupRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanExternalUsergroup{}
+	obj.Id = rand.Intn(100)
+	obj.UsergroupId = rand.Intn(100) + 1
+	s := ForemanExternalUsergroupToInstanceState(obj)
+
+	rd := MockForemanExternalUsergroupResourceData(s)
+	obj = *buildForemanExternalUsergroup(rd)
+	reqData, _ := api.WrapJson("external_usergroup", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupCreate",
+				crudFunc:     resourceForemanExternalUsergroupCreate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupUpdate",
+				crudFunc:     resourceForemanExternalUsergroupUpdate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanExternalUsergroupStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanExternalUsergroup{}
+	obj.Id = rand.Intn(100)
+	obj.UsergroupId = rand.Intn(100) + 1
+	s := ForemanExternalUsergroupToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupCreate",
+			crudFunc:     resourceForemanExternalUsergroupCreate,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupRead",
+			crudFunc:     resourceForemanExternalUsergroupRead,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupUpdate",
+			crudFunc:     resourceForemanExternalUsergroupUpdate,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupDelete",
+			crudFunc:     resourceForemanExternalUsergroupDelete,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanExternalUsergroupEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanExternalUsergroup{}
+	obj.Id = rand.Intn(100)
+	obj.UsergroupId = rand.Intn(100) + 1
+	s := ForemanExternalUsergroupToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupCreate",
+			crudFunc:     resourceForemanExternalUsergroupCreate,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupRead",
+			crudFunc:     resourceForemanExternalUsergroupRead,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanExternalUsergroupUpdate",
+			crudFunc:     resourceForemanExternalUsergroupUpdate,
+			resourceData: MockForemanExternalUsergroupResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanExternalUsergroupMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanExternalUsergroup()
+	s := ForemanExternalUsergroupToInstanceState(obj)
+
+	// the usergroup is not part of the response, it is kept from the state
+	expectedResourceDataFromFile := func(path string) *schema.ResourceData {
+		rd := MockForemanExternalUsergroupResourceDataFromFile(t, path)
+		rd.Set("usergroup_id", obj.UsergroupId)
+		return rd
+	}
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupCreate",
+				crudFunc:     resourceForemanExternalUsergroupCreate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			responseFile: ExternalUsergroupsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: expectedResourceDataFromFile(
+				ExternalUsergroupsTestDataPath + "/create_response.json",
+			),
+			compareFunc: ForemanExternalUsergroupResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupRead",
+				crudFunc:     resourceForemanExternalUsergroupRead,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			responseFile: ExternalUsergroupsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: expectedResourceDataFromFile(
+				ExternalUsergroupsTestDataPath + "/read_response.json",
+			),
+			compareFunc: ForemanExternalUsergroupResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanExternalUsergroupUpdate",
+				crudFunc:     resourceForemanExternalUsergroupUpdate,
+				resourceData: MockForemanExternalUsergroupResourceData(s),
+			},
+			responseFile: ExternalUsergroupsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: expectedResourceDataFromFile(
+				ExternalUsergroupsTestDataPath + "/update_response.json",
+			),
+			compareFunc: ForemanExternalUsergroupResourceDataCompare,
+		},
+	}
+
+}
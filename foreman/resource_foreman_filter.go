@@ -0,0 +1,270 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanFilter() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanFilterCreate,
+		Read:   resourceForemanFilterRead,
+		Update: resourceForemanFilterUpdate,
+		Delete: resourceForemanFilterDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A filter of a role. A filter grants a set of permissions on "+
+						"a single resource type, optionally limited to the objects "+
+						"matching a scoped search.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"search": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Scoped search limiting the objects the permissions apply to. "+
+						"The permissions apply to all objects of the resource type "+
+						"if not set. "+
+						"%s \"hostgroup_title ~ web*\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"override": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the filter overrides the role's " +
+					"taxonomies with its own `location_ids` and `organization_ids`. " +
+					"Defaults to `false`.",
+			},
+
+			"resource_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Resource type the filter's permissions apply to. " +
+					"Computed by Foreman from the filter's permissions.",
+			},
+
+			"unlimited": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether or not the filter applies to all objects of " +
+					"the resource type.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"role_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the role the filter belongs to.",
+			},
+
+			"permission_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the permissions granted by the filter. All " +
+					"permissions must apply to the same resource type.",
+			},
+
+			"location_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the filter is limited to. Only " +
+					"used if `override` is `true`, the filter inherits the role's " +
+					"locations otherwise.",
+			},
+
+			"organization_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the filter is limited to. " +
+					"Only used if `override` is `true`, the filter inherits the " +
+					"role's organizations otherwise.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanFilter constructs a ForemanFilter reference from a resource data
+// reference.  The struct's  members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanFilter(d *schema.ResourceData) *api.ForemanFilter {
+	log.Tracef("resource_foreman_filter.go#buildForemanFilter")
+
+	filter := api.ForemanFilter{}
+
+	obj := buildForemanObject(d)
+	filter.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("search"); ok {
+		filter.Search = attr.(string)
+	}
+
+	filter.Override = d.Get("override").(bool)
+
+	if attr, ok = d.GetOk("resource_type"); ok {
+		filter.ResourceType = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("unlimited"); ok {
+		filter.Unlimited = attr.(bool)
+	}
+
+	if attr, ok = d.GetOk("role_id"); ok {
+		filter.RoleId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("permission_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		filter.PermissionIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	filter.LocationIds = buildForemanIds(d, "location_ids")
+	filter.OrganizationIds = buildForemanIds(d, "organization_ids")
+
+	return &filter
+}
+
+// setResourceDataFromForemanFilter sets a ResourceData's attributes from the
+// attributes of the supplied ForemanFilter reference
+func setResourceDataFromForemanFilter(d *schema.ResourceData, ff *api.ForemanFilter) {
+	log.Tracef("resource_foreman_filter.go#setResourceDataFromForemanFilter")
+
+	d.SetId(strconv.Itoa(ff.Id))
+	d.Set("search", ff.Search)
+	d.Set("override", ff.Override)
+	d.Set("resource_type", ff.ResourceType)
+	d.Set("unlimited", ff.Unlimited)
+	d.Set("role_id", ff.RoleId)
+	d.Set("permission_ids", ff.PermissionIds)
+
+	// NOTE(ALL): A filter which does not override the role's taxonomies
+	//   inherits them from the role.  The inherited taxonomies are not
+	//   managed by the filter and are left out to not cause a diff.
+	if ff.Override {
+		d.Set("location_ids", ff.LocationIds)
+		d.Set("organization_ids", ff.OrganizationIds)
+	} else {
+		d.Set("location_ids", []int{})
+		d.Set("organization_ids", []int{})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanFilterCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_filter.go#Create")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	createdFilter, createErr := client.CreateFilter(f)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanFilter: [%+v]", createdFilter)
+
+	setResourceDataFromForemanFilter(d, createdFilter)
+
+	return nil
+}
+
+func resourceForemanFilterRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_filter.go#Read")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	readFilter, readErr := client.ReadFilter(f.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanFilter: [%+v]", readFilter)
+
+	setResourceDataFromForemanFilter(d, readFilter)
+
+	return nil
+}
+
+func resourceForemanFilterUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_filter.go#Update")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	updatedFilter, updateErr := client.UpdateFilter(f)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanFilter: [%+v]", updatedFilter)
+
+	setResourceDataFromForemanFilter(d, updatedFilter)
+
+	return nil
+}
+
+func resourceForemanFilterDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_filter.go#Delete")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteFilter(f.Id)
+}
@@ -0,0 +1,502 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const FiltersURI = api.FOREMAN_API_URL_PREFIX + "/filters"
+const FiltersTestDataPath = "testdata/1.11/filters"
+
+// Given a ForemanFilter, create a mock instance state reference
+func ForemanFilterToInstanceState(obj api.ForemanFilter) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanFilter
+	attr := map[string]string{}
+	attr["search"] = obj.Search
+	attr["override"] = strconv.FormatBool(obj.Override)
+	attr["resource_type"] = obj.ResourceType
+	attr["unlimited"] = strconv.FormatBool(obj.Unlimited)
+	attr["role_id"] = strconv.Itoa(obj.RoleId)
+	attr["permission_ids.#"] = strconv.Itoa(len(obj.PermissionIds))
+	for idx, val := range obj.PermissionIds {
+		key := fmt.Sprintf("permission_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["location_ids.#"] = strconv.Itoa(len(obj.LocationIds))
+	for idx, val := range obj.LocationIds {
+		key := fmt.Sprintf("location_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["organization_ids.#"] = strconv.Itoa(len(obj.OrganizationIds))
+	for idx, val := range obj.OrganizationIds {
+		key := fmt.Sprintf("organization_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanFilter resource, create a
+// mock ResourceData reference.
+func MockForemanFilterResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanFilter()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a filter
+// ResourceData reference
+func MockForemanFilterResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanFilter
+	ParseJSONFile(t, path, &obj)
+	s := ForemanFilterToInstanceState(obj)
+	return MockForemanFilterResourceData(s)
+}
+
+// Creates a random ForemanFilter struct
+func RandForemanFilter() api.ForemanFilter {
+	obj := api.ForemanFilter{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Search = "name ~ " + tfrand.String(10, tfrand.Lower)
+	obj.Override = rand.Intn(2) > 0
+	obj.ResourceType = "Host"
+	obj.Unlimited = false
+	obj.RoleId = rand.Intn(100) + 1
+	obj.PermissionIds = tfrand.IntArrayUnique(5)
+	// A filter which does not override the role's taxonomies does not manage
+	// them, see setResourceDataFromForemanFilter()
+	obj.LocationIds = []int{}
+	obj.OrganizationIds = []int{}
+	if obj.Override {
+		obj.LocationIds = tfrand.IntArrayUnique(5)
+		obj.OrganizationIds = tfrand.IntArrayUnique(5)
+	}
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanFilter resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanFilterResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanFilter()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"permission_ids", "location_ids", "organization_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestFilterUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanFilter
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanFilter UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanFilter UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanFilter
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanFilter
+func TestBuildForemanFilter(t *testing.T) {
+
+	expectedObj := RandForemanFilter()
+	expectedState := ForemanFilterToInstanceState(expectedObj)
+	expectedResourceData := MockForemanFilterResourceData(expectedState)
+
+	actualObj := *buildForemanFilter(expectedResourceData)
+
+	actualState := ForemanFilterToInstanceState(actualObj)
+	actualResourceData := MockForemanFilterResourceData(actualState)
+
+	ForemanFilterResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// Ensures location_ids and organization_ids are only sent when they are
+// configured or changed and an emptied list is sent to remove the taxonomies
+func TestBuildForemanFilter_TaxonomyIds(t *testing.T) {
+	r := resourceForemanFilter()
+	newConfig := func(taxonomyIds []interface{}) map[string]interface{} {
+		cfg := map[string]interface{}{
+			"role_id":        1,
+			"permission_ids": []interface{}{1},
+			"override":       true,
+		}
+		if taxonomyIds != nil {
+			cfg["location_ids"] = taxonomyIds
+			cfg["organization_ids"] = taxonomyIds
+		}
+		return cfg
+	}
+
+	testCases := []struct {
+		state    []interface{}
+		config   []interface{}
+		expected interface{}
+	}{
+		{nil, nil, nil},
+		{nil, []interface{}{3}, []interface{}{float64(3)}},
+		{[]interface{}{3}, []interface{}{3}, []interface{}{float64(3)}},
+		{[]interface{}{3}, []interface{}{}, []interface{}{}},
+		{[]interface{}{3}, nil, []interface{}{}},
+	}
+
+	for _, testCase := range testCases {
+		stateData := schema.TestResourceDataRaw(t, r.Schema, newConfig(testCase.state))
+		stateData.SetId("1")
+		state := stateData.State()
+
+		config := terraform.NewResourceConfigRaw(newConfig(testCase.config))
+		diff, _ := r.Diff(state, config, nil)
+		rd, _ := schema.InternalMap(r.Schema).Data(state, diff)
+
+		filterBytes, _ := json.Marshal(buildForemanFilter(rd))
+		var filterMap map[string]interface{}
+		json.Unmarshal(filterBytes, &filterMap)
+
+		for _, key := range []string{"location_ids", "organization_ids"} {
+			actual, sent := filterMap[key]
+			if (testCase.expected == nil && sent) || !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf(
+					"buildForemanFilter() with state [%v] and config [%v] sent the "+
+						"wrong %s. Expected [%v], got [%v]",
+					testCase.state,
+					testCase.config,
+					key,
+					testCase.expected,
+					actual,
+				)
+			}
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanFilter
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanFilter_Value(t *testing.T) {
+
+	expectedObj := RandForemanFilter()
+	expectedState := ForemanFilterToInstanceState(expectedObj)
+	expectedResourceData := MockForemanFilterResourceData(expectedState)
+
+	actualObj := api.ForemanFilter{}
+	actualState := ForemanFilterToInstanceState(actualObj)
+	actualResourceData := MockForemanFilterResourceData(actualState)
+
+	setResourceDataFromForemanFilter(actualResourceData, &expectedObj)
+
+	ForemanFilterResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanFilterCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+	filtersURIById := FiltersURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterCreate",
+				crudFunc:     resourceForemanFilterCreate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURI:    FiltersURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURI:    filtersURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterUpdate",
+				crudFunc:     resourceForemanFilterUpdate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURI:    filtersURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterDelete",
+				crudFunc:     resourceForemanFilterDelete,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURI:    filtersURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanFilterRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanFilterRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+
+	rd := MockForemanFilterResourceData(s)
+	obj = *buildForemanFilter(rd)
+	reqData, _ := api.WrapJson("filter", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterCreate",
+				crudFunc:     resourceForemanFilterCreate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterUpdate",
+				crudFunc:     resourceForemanFilterUpdate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanFilterStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanFilterEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanFilterMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterCreate",
+				crudFunc:     resourceForemanFilterCreate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			responseFile: FiltersTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanFilterResourceDataFromFile(
+				t,
+				FiltersTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanFilterResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			responseFile: FiltersTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanFilterResourceDataFromFile(
+				t,
+				FiltersTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanFilterResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterUpdate",
+				crudFunc:     resourceForemanFilterUpdate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			responseFile: FiltersTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanFilterResourceDataFromFile(
+				t,
+				FiltersTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanFilterResourceDataCompare,
+		},
+	}
+
+}
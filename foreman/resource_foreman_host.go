@@ -1,14 +1,19 @@
 package foreman
 
 import (
+	"bytes"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
@@ -22,7 +27,14 @@ func resourceForemanHost() *schema.Resource {
 		Delete: resourceForemanHostDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceForemanHostImport,
+		},
+
+		CustomizeDiff: resourceForemanHostCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -39,12 +51,26 @@ func resourceForemanHost() *schema.Resource {
 			// -- Required --
 
 			"name": &schema.Schema{
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				DiffSuppressFunc: suppressForemanHostFQDNName,
+				Description: fmt.Sprintf(
+					"Name of the host without its domain. A fully qualified "+
+						"domain name matching `fqdn` is treated as the same name. "+
+						"%s \"compute01\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			// -- Computed --
+
+			"fqdn": &schema.Schema{
 				Type:     schema.TypeString,
-				ForceNew: true,
-				Required: true,
+				Computed: true,
 				Description: fmt.Sprintf(
-					"Host fully qualified domain name. "+
-						"%s \"compute01.dc1.company.com\"",
+					"Fully qualified domain name of the host as reported by "+
+						"Foreman. %s \"compute01.dc1.company.com\"",
 					autodoc.MetaExample,
 				),
 			},
@@ -74,12 +100,14 @@ func resourceForemanHost() *schema.Resource {
 				),
 			},
 			"parameters": &schema.Schema{
-				Type:     schema.TypeMap,
-				ForceNew: false,
-				Optional: true,
+				Type:          schema.TypeMap,
+				ForceNew:      false,
+				Optional:      true,
+				ConflictsWith: []string{"parameter"},
 				Description: "A map of parameters that will be saved as host parameters " +
 					"in the machine config.",
 			},
+			"parameter": foremanParameterSchema("host"),
 
 			"enable_bmc": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -90,12 +118,66 @@ func resourceForemanHost() *schema.Resource {
 					"boot to PXE and power on. Defaults to `false`.",
 			},
 
+			"wait_for_build": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait on create and on rebuilds triggered by " +
+					"`rebuild_trigger` until Foreman reports the host as built, i.e. the " +
+					"build flag is cleared and the installation time is set. Only useful " +
+					"with the provision method `\"build\"`. How long to wait is set with " +
+					"the `create` and `update` resource timeouts, which default to 60 " +
+					"minutes. Defaults to `false`.",
+			},
+
+			"rebuild_trigger": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: fmt.Sprintf(
+					"Arbitrary map of values. Any change to the map puts the existing "+
+						"host into build mode, keeping its ID, facts and reports instead of "+
+						"re-creating it. "+
+						"%s { image_version = \"2020.1\" }",
+					autodoc.MetaExample,
+				),
+			},
+
+			"rebuild_power_cycle": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Power cycle the host after a rebuild was triggered by " +
+					"`rebuild_trigger`. If `enable_bmc` is set, the next boot device is " +
+					"set to PXE first. Defaults to `false`.",
+			},
+
+			"power_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					api.PowerOn,
+					api.PowerOff,
+					// NOTE(ALL): false - do not ignore case when comparing values
+				}, false),
+				Description: "The desired power state of the host. The host is " +
+					"powered on or off through its BMC or compute resource when the " +
+					"actual state differs. If set, the actual state is read back on " +
+					"every refresh. Options are `\"on\"` and `\"off\"`.",
+			},
+
 			"retry_count": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      2,
-				Description:  "Number of times to retry on a failed attempt to register or delete a host in foreman.",
 				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Number of times to check, every 2 seconds, whether a " +
+					"deleted host is gone from Foreman. The host's interfaces are " +
+					"removed along with it. Failed API requests are retried " +
+					"according to the provider's `client_max_retries` setting.",
 			},
 
 			"bmc_success": &schema.Schema{
@@ -198,15 +280,27 @@ func resourceForemanHost() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
+			"organization_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the organization to assign to the host.",
+			},
 
 			// -- Key Components --
+
+			// NOTE(ALL): Interfaces are keyed by their identifier.  Changing an
+			//   interface with the same identifier updates it in place, adding
+			//   or removing an identifier adds or removes the interface from the
+			//   host.
 			"interfaces_attributes": &schema.Schema{
-				Type:        schema.TypeSet,
-				Optional:    true,
-				ForceNew:    true,
-				Elem:        resourceForemanInterfacesAttributes(),
-				Set:         schema.HashResource(resourceForemanInterfacesAttributes()),
-				Description: "Host interface information.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     resourceForemanInterfacesAttributes(),
+				Set:      hashForemanInterfacesAttribute,
+				Description: "Host interface information. Interfaces are identified by " +
+					"their `identifier` and updated in place.",
 			},
 		},
 	}
@@ -232,28 +326,25 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 			"primary": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     false,
 				Description: "Whether or not this is the primary interface.",
 			},
 			"ip": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.SingleIP(),
 				Description:  "IP address associated with the interface.",
 			},
 			"mac": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Computed:    true,
-				Description: "MAC address associated with the interface.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateMAC,
+				Description:  "MAC address associated with the interface.",
 			},
 			"subnet_id": &schema.Schema{
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the subnet to associate with this interface.",
@@ -261,59 +352,50 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 			"identifier": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Identifier of this interface local to the host.",
 			},
 			"managed": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     false,
 				Description: "Whether or not this interface is managed by Foreman.",
 			},
 			"provision": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     false,
 				Description: "Whether or not this interface is used to provision the host.",
 			},
 			"virtual": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     false,
 				Description: "Whether or not this is a virtual interface.",
 			},
 			"attached_to": &schema.Schema{
 				Type:        schema.TypeString,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Identifier of the interface to which this interface belongs.",
 			},
 			"attached_devices": &schema.Schema{
 				Type:        schema.TypeString,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Identifiers of attached interfaces, e.g. 'eth1', 'eth2' as comma-separated list",
 			},
 			"username": &schema.Schema{
 				Type:        schema.TypeString,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Username used for BMC/IPMI functionality.",
 			},
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
 				Sensitive:   true,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Associated password used for BMC/IPMI functionality.",
 			},
 			"type": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					"interface",
 					"bmc",
@@ -328,7 +410,6 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 			"bmc_provider": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					"IPMI",
 					// NOTE(ALL): false - do not ignore case when comparing values
@@ -345,6 +426,217 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 	}
 }
 
+// hashForemanInterfacesAttribute is the hash function of the
+// "interfaces_attributes" set.  Interfaces with an identifier are hashed by
+// their identifier only, so changing any other attribute of the interface is
+// an in-place update rather than a replacement of the set element.
+func hashForemanInterfacesAttribute(v interface{}) int {
+	m := v.(map[string]interface{})
+	if identifier, ok := m["identifier"].(string); ok && identifier != "" {
+		return hashcode.String(identifier)
+	}
+	return schema.HashResource(resourceForemanInterfacesAttributes())(v)
+}
+
+// foremanInterfacesAttributeKey returns the key an interface is matched by
+// between the old and new "interfaces_attributes": its identifier, or its ID
+// if it has no identifier.  Interfaces which have neither are new interfaces
+// and have no key.
+func foremanInterfacesAttributeKey(iface api.ForemanInterfacesAttribute) string {
+	if iface.Identifier != "" {
+		return iface.Identifier
+	}
+	if iface.Id > 0 {
+		return "id:" + strconv.Itoa(iface.Id)
+	}
+	return ""
+}
+
+// validateMAC is a SchemaValidateFunc which ensures the value is a MAC address
+// Foreman accepts, ie: "c0:ff:ee:ba:be:00".
+func validateMAC(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if _, parseErr := net.ParseMAC(v); parseErr != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a MAC address, got %s", k, v)}
+	}
+	return nil, nil
+}
+
+// -----------------------------------------------------------------------------
+// Plan Time Validation
+// -----------------------------------------------------------------------------
+
+// resourceForemanHostCustomizeDiff validates the typed parameters and the
+// interfaces of a host during plan so mistakes surface before Foreman rejects
+// the host with a 422.
+//
+// NOTE(ALL): Interface attributes which are not known yet (ie: an IP address
+//   suggested by a data source which is read during apply) are read as a
+//   placeholder value.  IP and MAC addresses which do not parse are skipped,
+//   invalid addresses are already rejected by the attribute validation.
+func resourceForemanHostCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	log.Tracef("resource_foreman_host.go#CustomizeDiff")
+
+	if paramErr := customizeDiffForemanParameters(d, meta); paramErr != nil {
+		return paramErr
+	}
+
+	if !d.HasChange("interfaces_attributes") {
+		return nil
+	}
+
+	attrSet, ok := d.Get("interfaces_attributes").(*schema.Set)
+	if !ok || attrSet.Len() == 0 {
+		return nil
+	}
+	interfaces := make([]api.ForemanInterfacesAttribute, attrSet.Len())
+	for idx, attrMap := range attrSet.List() {
+		interfaces[idx] = mapToForemanInterfacesAttribute(attrMap.(map[string]interface{}))
+	}
+
+	if validateErr := validateForemanHostInterfaces(interfaces); validateErr != nil {
+		return validateErr
+	}
+
+	client := meta.(*api.Client)
+	return validateForemanHostInterfaceSubnets(client, interfaces)
+}
+
+// validateForemanHostInterfaces ensures a host has exactly one primary and one
+// provision interface and that no two interfaces share an IP address or
+// (unless virtual) a MAC address.
+func validateForemanHostInterfaces(interfaces []api.ForemanInterfacesAttribute) error {
+	log.Tracef("resource_foreman_host.go#validateForemanHostInterfaces")
+
+	var numPrimary, numProvision int
+	ips := map[string]bool{}
+	macs := map[string]bool{}
+
+	for _, iface := range interfaces {
+		if iface.Primary {
+			numPrimary++
+		}
+		if iface.Provision {
+			numProvision++
+		}
+
+		if ip := net.ParseIP(iface.IP); ip != nil {
+			if ips[ip.String()] {
+				return fmt.Errorf("IP address [%s] is used by more than one interface", iface.IP)
+			}
+			ips[ip.String()] = true
+		}
+
+		// NOTE(ALL): virtual interfaces, bonds and bridges share the MAC
+		//   address of a physical interface
+		if iface.Virtual || iface.Type == "bond" || iface.Type == "bridge" {
+			continue
+		}
+		if hwAddr, parseErr := net.ParseMAC(iface.MAC); parseErr == nil {
+			if macs[hwAddr.String()] {
+				return fmt.Errorf("MAC address [%s] is used by more than one interface", iface.MAC)
+			}
+			macs[hwAddr.String()] = true
+		}
+	}
+
+	if numPrimary != 1 {
+		return fmt.Errorf(
+			"Host must have exactly one primary interface, got [%d]",
+			numPrimary,
+		)
+	}
+	if numProvision != 1 {
+		return fmt.Errorf(
+			"Host must have exactly one provision interface, got [%d]",
+			numProvision,
+		)
+	}
+
+	return nil
+}
+
+// validateForemanHostInterfaceSubnets ensures the IP address of each interface
+// lies within the network of its subnet and, if the subnet suggests IP
+// addresses through IPAM, within the subnet's from/to range.
+func validateForemanHostInterfaceSubnets(client *api.Client, interfaces []api.ForemanInterfacesAttribute) error {
+	log.Tracef("resource_foreman_host.go#validateForemanHostInterfaceSubnets")
+
+	subnets := map[int]*api.ForemanSubnet{}
+
+	for _, iface := range interfaces {
+		ip := net.ParseIP(iface.IP)
+		if ip == nil || iface.SubnetId == 0 {
+			continue
+		}
+
+		subnet, ok := subnets[iface.SubnetId]
+		if !ok {
+			var readErr error
+			subnet, readErr = client.ReadSubnet(iface.SubnetId)
+			if readErr != nil {
+				return fmt.Errorf(
+					"Failed to read subnet [%d] of interface with IP address [%s]: %s",
+					iface.SubnetId,
+					iface.IP,
+					readErr,
+				)
+			}
+			subnets[iface.SubnetId] = subnet
+		}
+
+		network := &net.IPNet{
+			IP:   net.ParseIP(subnet.Network),
+			Mask: ipMaskFromString(subnet.Mask),
+		}
+		if network.IP == nil || network.Mask == nil || !network.Contains(ip) {
+			return fmt.Errorf(
+				"IP address [%s] is not within subnet [%s] (%s/%s)",
+				iface.IP,
+				subnet.Name,
+				subnet.Network,
+				subnet.Mask,
+			)
+		}
+
+		if subnet.Ipam == "" || subnet.Ipam == "None" || subnet.From == "" || subnet.To == "" {
+			continue
+		}
+		from := net.ParseIP(subnet.From).To16()
+		to := net.ParseIP(subnet.To).To16()
+		if bytes.Compare(ip.To16(), from) < 0 || bytes.Compare(ip.To16(), to) > 0 {
+			return fmt.Errorf(
+				"IP address [%s] is not within the range [%s - %s] of subnet [%s]",
+				iface.IP,
+				subnet.From,
+				subnet.To,
+				subnet.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ipMaskFromString converts a netmask in its address notation (ie:
+// "255.255.255.0") to a net.IPMask.  Returns nil for an invalid netmask.
+func ipMaskFromString(mask string) net.IPMask {
+	ip := net.ParseIP(mask)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return net.IPMask(ip4)
+	}
+	return net.IPMask(ip)
+}
+
 // -----------------------------------------------------------------------------
 // Conversion Helpers
 // -----------------------------------------------------------------------------
@@ -401,15 +693,10 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	if attr, ok = d.GetOk("location_id"); ok {
 		host.LocationId = attr.(int)
 	}
-	if attr, ok = d.GetOk("parameters"); ok {
-		hostTags := d.Get("parameters").(map[string]interface{})
-		for key, value := range hostTags {
-			host.HostParameters = append(host.HostParameters, api.ForemanKVParameter{
-				Name:  key,
-				Value: value.(string),
-			})
-		}
+	if attr, ok = d.GetOk("organization_id"); ok {
+		host.OrganizationId = attr.(int)
 	}
+	host.HostParameters = buildForemanKVParameters(d)
 
 	host.InterfacesAttributes = buildForemanInterfacesAttributes(d)
 
@@ -432,10 +719,15 @@ func buildForemanInterfacesAttributes(d *schema.ResourceData) []api.ForemanInter
 	}
 
 	// type assert the underlying *schema.Set and convert to a list
-	attrSet := attr.(*schema.Set)
+	return setToForemanInterfacesAttributes(attr.(*schema.Set))
+}
+
+// setToForemanInterfacesAttributes converts the *schema.Set of the
+// "interfaces_attributes" property to an array of ForemanInterfacesAttribute
+// structs.
+func setToForemanInterfacesAttributes(attrSet *schema.Set) []api.ForemanInterfacesAttribute {
 	attrList := attrSet.List()
-	attrListLen := len(attrList)
-	tempIntAttr = make([]api.ForemanInterfacesAttribute, attrListLen)
+	tempIntAttr := make([]api.ForemanInterfacesAttribute, len(attrList))
 	// iterate over each of the map structure entires in the set and convert that
 	// to a concrete struct implementation to append to the interfaces
 	// attributes list.
@@ -443,10 +735,49 @@ func buildForemanInterfacesAttributes(d *schema.ResourceData) []api.ForemanInter
 		tempIntAttrMap := attrMap.(map[string]interface{})
 		tempIntAttr[idx] = mapToForemanInterfacesAttribute(tempIntAttrMap)
 	}
-
 	return tempIntAttr
 }
 
+// mergeForemanInterfacesAttributes builds the interfaces to send to Foreman
+// when the interfaces of a host change.  Interfaces of the new list matching
+// an old interface by key (see foremanInterfacesAttributeKey) receive the ID
+// of the old interface and are updated in place.  Interfaces of the new list
+// without a match are created.  Old interfaces without a match in the new
+// list are sent with the "_destroy" flag to remove them from the host.
+func mergeForemanInterfacesAttributes(oldList, newList []api.ForemanInterfacesAttribute) []api.ForemanInterfacesAttribute {
+	log.Tracef("resource_foreman_host.go#mergeForemanInterfacesAttributes")
+
+	oldByKey := map[string]api.ForemanInterfacesAttribute{}
+	for _, iface := range oldList {
+		if key := foremanInterfacesAttributeKey(iface); key != "" {
+			oldByKey[key] = iface
+		}
+	}
+
+	merged := []api.ForemanInterfacesAttribute{}
+	for _, iface := range newList {
+		key := foremanInterfacesAttributeKey(iface)
+		if oldIface, ok := oldByKey[key]; ok && key != "" {
+			iface.Id = oldIface.Id
+			delete(oldByKey, key)
+		}
+		merged = append(merged, iface)
+	}
+
+	// NOTE(ALL): iterate over the old list rather than the map to send the
+	//   removed interfaces in a stable order
+	for _, iface := range oldList {
+		key := foremanInterfacesAttributeKey(iface)
+		if _, ok := oldByKey[key]; !ok || iface.Id == 0 {
+			continue
+		}
+		iface.Destroy = true
+		merged = append(merged, iface)
+	}
+
+	return merged
+}
+
 // mapToForemanInterfacesAttribute converts a map[string]interface{} to a
 // ForemanInterfacesAttribute struct.  The supplied map comes from an entry in
 // the *schema.Set for the "interfaces_attributes" property of the resource,
@@ -553,6 +884,27 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 	return tempIntAttr
 }
 
+// suppressForemanHostFQDNName is a DiffSuppressFunc for the "name" of a host.
+// A configured FQDN is the same name as the short name Foreman reads back, ie:
+// for a host imported by its FQDN.
+func suppressForemanHostFQDNName(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" {
+		return false
+	}
+	fqdn, _ := d.Get("fqdn").(string)
+	return new == fqdn && strings.HasPrefix(fqdn, old+".")
+}
+
+// foremanHostFQDN returns the fully qualified domain name of a host.  Foreman
+// returns the name of a host as its FQDN, which is split into the name and
+// the domain name when unmarshalling.
+func foremanHostFQDN(fh *api.ForemanHost) string {
+	if fh.DomainName == "" {
+		return fh.Name
+	}
+	return fh.Name + "." + fh.DomainName
+}
+
 // setResourceDataFromForemanHost sets a ResourceData's attributes from the
 // attributes of the supplied ForemanHost struct
 func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost) {
@@ -561,8 +913,14 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.SetId(strconv.Itoa(fh.Id))
 
 	d.Set("name", fh.Name)
+	d.Set("fqdn", foremanHostFQDN(fh))
 	d.Set("comment", fh.Comment)
-	d.Set("parameters", fh.HostParameters)
+	// NOTE(ALL): The provision method is only read when it is not known yet,
+	//   ie: after an import.  Older Foreman versions do not report it.
+	if _, ok := d.GetOk("method"); !ok {
+		d.Set("method", fh.Method)
+	}
+	setForemanKVParameters(d, fh.HostParameters)
 	d.Set("domain_id", fh.DomainId)
 	d.Set("realm_id", fh.RealmId)
 	d.Set("environment_id", fh.EnvironmentId)
@@ -570,6 +928,7 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("compute_resource_id", fh.ComputeResourceId)
 	d.Set("compute_profile_id", fh.ComputeProfileId)
 	d.Set("location_id", fh.LocationId)
+	d.Set("organization_id", fh.OrganizationId)
 	d.Set("operatingsystem_id", fh.OperatingSystemId)
 	d.Set("medium_id", fh.MediumId)
 	d.Set("image_id", fh.ImageId)
@@ -577,8 +936,11 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 
 	// In partial mode, flag keys below as completed successfully
 	d.SetPartial("name")
+	d.SetPartial("fqdn")
+	d.SetPartial("method")
 	d.SetPartial("comment")
 	d.SetPartial("parameters")
+	d.SetPartial("parameter")
 	d.SetPartial("domain_id")
 	d.SetPartial("realm_id")
 	d.SetPartial("environment_id")
@@ -586,6 +948,7 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.SetPartial("compute_resource_id")
 	d.SetPartial("compute_profile_id")
 	d.SetPartial("location_id")
+	d.SetPartial("organization_id")
 	d.SetPartial("operatingsystem_id")
 	d.SetPartial("medium_id")
 	d.SetPartial("image_id")
@@ -599,15 +962,38 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 // "interfaces_attributes" attribute to the value of the supplied array of
 // ForemanInterfacesAttribute structs
 func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia []api.ForemanInterfacesAttribute) {
+	// NOTE(ALL): Foreman does not return the BMC password and the compute
+	//   attributes of an interface.  Keep the values known to terraform so
+	//   they do not show up as a change on every plan.
+	known := map[string]api.ForemanInterfacesAttribute{}
+	if attr, ok := d.GetOk("interfaces_attributes"); ok {
+		for _, iface := range setToForemanInterfacesAttributes(attr.(*schema.Set)) {
+			if key := foremanInterfacesAttributeKey(iface); key != "" {
+				known[key] = iface
+			}
+		}
+	}
+
 	// this attribute is a *schema.Set.  In order to construct a set, we need to
 	// supply a hash function so the set can differentiate for uniqueness of
-	// entries.  The hash function will be based on the resource definition
-	hashFunc := schema.HashResource(resourceForemanInterfacesAttributes())
+	// entries.
+	hashFunc := hashForemanInterfacesAttribute
 	// underneath, a *schema.Set stores an array of map[string]interface{} entries.
 	// convert each ForemanInterfaces struct in the supplied array to a
 	// mapstructure and then add it to the set
 	ifaceArr := make([]interface{}, len(fhia))
 	for idx, val := range fhia {
+		knownIface, ok := known[foremanInterfacesAttributeKey(val)]
+		if !ok {
+			knownIface = known["id:"+strconv.Itoa(val.Id)]
+		}
+		if val.Password == "" {
+			val.Password = knownIface.Password
+		}
+		if val.ComputeAttributes == nil {
+			val.ComputeAttributes = knownIface.ComputeAttributes
+		}
+
 		// NOTE(ALL): we ommit the "_destroy" property here - this does not need
 		//   to be stored by terraform in the state file. That is a hidden key that
 		//   is only used in updates.  Anything that exists will always have it
@@ -616,7 +1002,7 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia
 			"id":           val.Id,
 			"ip":           val.IP,
 			"mac":          val.MAC,
-			"name":         val.Name,
+			"identifier":   val.Identifier,
 			"subnet_id":    val.SubnetId,
 			"primary":      val.Primary,
 			"managed":      val.Managed,
@@ -648,6 +1034,59 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fhia
 // Resource CRUD Operations
 // -----------------------------------------------------------------------------
 
+// resourceForemanHostImport imports a host by its ID or its FQDN.  A host
+// imported by its FQDN is searched by name to resolve its ID.  The attributes
+// which only control how the resource provisions a host are set to their
+// defaults, so an imported host plans clean.
+func resourceForemanHostImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_host.go#Import")
+
+	if _, atoiErr := strconv.Atoi(d.Id()); atoiErr != nil {
+		client := meta.(*api.Client)
+		hostId, searchErr := searchForemanHostId(client, `name="`+d.Id()+`"`)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		d.SetId(strconv.Itoa(hostId))
+	}
+
+	d.Set("enable_bmc", false)
+	d.Set("bmc_success", true)
+	d.Set("wait_for_build", false)
+	d.Set("rebuild_power_cycle", false)
+	d.Set("retry_count", 2)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// searchForemanHostId returns the ID of the only host matching the Foreman
+// scoped search expression.  An error is returned if no host or more than one
+// host matches.
+func searchForemanHostId(client *api.Client, search string) (int, error) {
+	log.Debugf("search: [%s]", search)
+
+	queryResponse, queryErr := client.SearchHosts(search, "")
+	if queryErr != nil {
+		return 0, queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return 0, fmt.Errorf("No host matches the search [%s]", search)
+	} else if queryResponse.Subtotal > 1 {
+		return 0, fmt.Errorf("More than 1 host matches the search [%s]", search)
+	}
+
+	queryHost, ok := queryResponse.Results[0].(api.ForemanHost)
+	if !ok {
+		return 0, fmt.Errorf(
+			"Search results contain unexpected type. Expected "+
+				"[api.ForemanHost], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	return queryHost.Id, nil
+}
+
 func resourceForemanHostCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_host.go#Create")
 
@@ -660,9 +1099,8 @@ func resourceForemanHostCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Debugf("ForemanHost: [%+v]", h)
-	hostRetryCount := d.Get("retry_count").(int)
 
-	createdHost, createErr := client.CreateHost(h, hostRetryCount)
+	createdHost, createErr := client.CreateHost(h)
 	if createErr != nil {
 		return createErr
 	}
@@ -696,7 +1134,7 @@ func resourceForemanHostCreate(d *schema.ResourceData, meta interface{}) error {
 	// Loop through each of the above BMC Operations and execute.
 	// In the event fo any failure, exit with error
 	for _, cmd := range powerCmds {
-		sendErr := client.SendPowerCommand(createdHost, cmd, hostRetryCount)
+		sendErr := client.SendPowerCommand(createdHost, cmd)
 		if sendErr != nil {
 			return sendErr
 		}
@@ -712,9 +1150,109 @@ func resourceForemanHostCreate(d *schema.ResourceData, meta interface{}) error {
 	// Disable partial mode
 	d.Partial(false)
 
+	if d.Get("wait_for_build").(bool) {
+		waitErr := waitForHostBuild(client, createdHost.Id, d.Timeout(schema.TimeoutCreate))
+		if waitErr != nil {
+			return waitErr
+		}
+	}
+
+	if powerState, ok := d.GetOk("power_state"); ok {
+		state, powerErr := convergeHostPowerState(client, createdHost, powerState.(string))
+		if powerErr != nil {
+			return powerErr
+		}
+		d.Set("power_state", state)
+	}
+
 	return nil
 }
 
+// convergeHostPowerState powers the host on or off if its actual power state
+// differs from the desired state.  Returns the resulting power state of the
+// host.
+func convergeHostPowerState(client *api.Client, h *api.ForemanHost, desired string) (string, error) {
+	log.Tracef("resource_foreman_host.go#convergeHostPowerState")
+
+	state, readErr := client.ReadPowerState(h)
+	if readErr != nil {
+		return "", readErr
+	}
+	if state == desired {
+		return state, nil
+	}
+
+	log.Debugf("Powering host [%d] %s, current state: [%s]", h.Id, desired, state)
+
+	sendErr := client.SendPowerCommand(h, api.Power{PowerAction: desired})
+	if sendErr != nil {
+		return state, sendErr
+	}
+	return desired, nil
+}
+
+// hostBuildPollInterval is the time between two checks whether a host has
+// finished building
+var hostBuildPollInterval = 30 * time.Second
+
+// waitForHostBuild polls the host with the given ID until Foreman reports it
+// as built - the build flag is cleared and the installation time is set.  An
+// error is returned if the build failed, the build token expired or the host
+// did not finish building within the timeout.  The error carries the host's
+// build status and the time of its last report.
+func waitForHostBuild(client *api.Client, id int, timeout time.Duration) error {
+	log.Tracef("resource_foreman_host.go#waitForHostBuild")
+
+	var lastHost *api.ForemanHost
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"built"},
+		Timeout:      timeout,
+		PollInterval: hostBuildPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			h, readErr := client.ReadHost(id)
+			if readErr != nil {
+				return nil, "", readErr
+			}
+			lastHost = h
+			log.Debugf(
+				"Host [%d] build: [%t], build status: [%s], installed at: [%s]",
+				id,
+				h.Build,
+				h.BuildStatusLabel,
+				h.InstalledAt,
+			)
+
+			if !h.Build && h.InstalledAt != "" {
+				return h, "built", nil
+			}
+			if h.BuildStatus == api.HostBuildStatusFailed ||
+				h.BuildStatus == api.HostBuildStatusTokenExpired {
+				return h, "failed", fmt.Errorf(
+					"Host [%s] failed to build. Build status: [%s], last report: [%s]",
+					h.Name,
+					h.BuildStatusLabel,
+					h.LastReport,
+				)
+			}
+			return h, "pending", nil
+		},
+	}
+
+	_, waitErr := stateConf.WaitForState()
+	if _, ok := waitErr.(*resource.TimeoutError); ok && lastHost != nil {
+		return fmt.Errorf(
+			"Timed out after [%s] waiting for host [%s] to build. Build status: "+
+				"[%s], last report: [%s]",
+			timeout,
+			lastHost.Name,
+			lastHost.BuildStatusLabel,
+			lastHost.LastReport,
+		)
+	}
+	return waitErr
+}
+
 func resourceForemanHostRead(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_host.go#Read")
 
@@ -725,13 +1263,31 @@ func resourceForemanHostRead(d *schema.ResourceData, meta interface{}) error {
 
 	readHost, readErr := client.ReadHost(h.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanHost: [%+v]", readHost)
 
 	setResourceDataFromForemanHost(d, readHost)
 
+	// NOTE(ALL): Not every host can report its power state.  Only read it if
+	//   the power state is managed by the configuration.  A BMC or compute
+	//   resource which is temporarily unreachable does not fail the refresh,
+	//   the previous power state is kept instead.
+	if _, ok := d.GetOk("power_state"); ok {
+		state, powerErr := client.ReadPowerState(readHost)
+		if powerErr != nil {
+			log.Warningf(
+				"Failed to read the power state of host [%d], keeping the "+
+					"previous state: %s",
+				readHost.Id,
+				powerErr,
+			)
+		} else {
+			d.Set("power_state", state)
+		}
+	}
+
 	return nil
 }
 
@@ -746,54 +1302,38 @@ func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
 	// Enable partial mode in the event of failure of one of API calls required for host update
 	d.Partial(true)
 
-	// NOTE(ALL): Handling the removal of a Interfaces.  See the note
-	//   in ForemanInterfacesAttribute's Destroy property
-	if d.HasChange("interfaces_attributes") {
-		oldVal, newVal := d.GetChange("interfaces_attributes")
-		oldValSet, newValSet := oldVal.(*schema.Set), newVal.(*schema.Set)
-
-		// NOTE(ALL): The set difference operation is anticommutative (because math)
-		//   ie: [A - B] =/= [B - A].
-		//
-		//   When performing an update, we need to figure out which interfaces
-		//   were removed from the set and tag the destroy property
-		//   to true and instruct Foreman which ones to delete from the list. We do
-		//   this by performing a set difference between the old set and the new
-		//   set (ie: [old - new]) which will return the items that used to be in
-		//   the set but are no longer included.
-		//
-		//   The values that were added to the set or remained unchanged are already
-		//   part of the interfaces.  They are present in the
-		//   ResourceData and already exist from the
-		//   buildForemanHost() call.
-
-		setDiff := oldValSet.Difference(newValSet)
-		setDiffList := setDiff.List()
-		log.Debugf("setDiffList: [%v]", setDiffList)
-
-		// iterate over the removed items, add them back to the interface's
-		// array, but tag them for removal.
-		//
-		// each of the set's items is stored as a map[string]interface{} - use
-		// type assertion and construct the struct
-		for _, rmVal := range setDiffList {
-			// construct, tag for deletion from list of interfaces
-			rmValMap := rmVal.(map[string]interface{})
-			rmInterface := mapToForemanInterfacesAttribute(rmValMap)
-			rmInterface.Destroy = true
-			// append back to interface's list
-			h.InterfacesAttributes = append(h.InterfacesAttributes, rmInterface)
+	// NOTE(ALL): Parameters are only sent to Foreman when they changed.  The
+	//   IDs of the existing parameters are read from Foreman to update and
+	//   remove them.
+	if d.HasChange("parameters") || d.HasChange("parameter") {
+		currentHost, readErr := client.ReadHost(h.Id)
+		if readErr != nil {
+			return readErr
 		}
+		h.HostParameters = reconcileForemanKVParameters(currentHost.HostParameters, h.HostParameters)
+	} else {
+		h.HostParameters = nil
+	} // end HasChange("parameters") || HasChange("parameter")
 
+	// NOTE(ALL): Interfaces are only sent to Foreman when they changed.  See
+	//   the note in ForemanInterfacesAttribute's Destroy property
+	if d.HasChange("interfaces_attributes") {
+		oldVal, newVal := d.GetChange("interfaces_attributes")
+		h.InterfacesAttributes = mergeForemanInterfacesAttributes(
+			setToForemanInterfacesAttributes(oldVal.(*schema.Set)),
+			setToForemanInterfacesAttributes(newVal.(*schema.Set)),
+		)
+		log.Debugf("InterfacesAttributes: [%+v]", h.InterfacesAttributes)
+	} else {
+		h.InterfacesAttributes = nil
 	} // end HasChange("interfaces_attributes")
 
-	hostRetryCount := d.Get("retry_count").(int)
-
 	// We need to test whether a call to update the host is necessary based on what has changed.
 	// Otherwise, a detected update caused by a unsuccessful BMC operation will cause a 422 on update.
 	if d.HasChange("name") ||
 		d.HasChange("comment") ||
 		d.HasChange("parameters") ||
+		d.HasChange("parameter") ||
 		d.HasChange("domain_id") ||
 		d.HasChange("realm_id") ||
 		d.HasChange("environment_id") ||
@@ -801,12 +1341,20 @@ func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
 		d.HasChange("compute_resource_id") ||
 		d.HasChange("compute_profile_id") ||
 		d.HasChange("location_id") ||
+		d.HasChange("organization_id") ||
 		d.HasChange("operatingsystem_id") ||
-		d.HasChange("interfaces_attributes") {
+		d.HasChange("interfaces_attributes") ||
+		d.HasChange("rebuild_trigger") {
+
+		// NOTE(ALL): Put the host back into build mode when a rebuild is
+		//   triggered
+		if d.HasChange("rebuild_trigger") {
+			h.Build = true
+		}
 
 		log.Debugf("host: [%+v]", h)
 
-		updatedHost, updateErr := client.UpdateHost(h, hostRetryCount)
+		updatedHost, updateErr := client.UpdateHost(h)
 		if updateErr != nil {
 			return updateErr
 		}
@@ -845,7 +1393,7 @@ func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		for _, cmd := range powerCmds {
-			sendErr := client.SendPowerCommand(h, cmd, hostRetryCount)
+			sendErr := client.SendPowerCommand(h, cmd)
 			if sendErr != nil {
 				return sendErr
 			}
@@ -857,47 +1405,80 @@ func resourceForemanHostUpdate(d *schema.ResourceData, meta interface{}) error {
 		d.SetPartial("bmc_success")
 
 	} // end HasChange("bmc_success")
+
+	if d.HasChange("rebuild_trigger") {
+		rebuildErr := rebuildForemanHost(d, client, h)
+		if rebuildErr != nil {
+			return rebuildErr
+		}
+	} // end HasChange("rebuild_trigger")
+
+	if d.HasChange("power_state") {
+		if powerState, ok := d.GetOk("power_state"); ok {
+			state, powerErr := convergeHostPowerState(client, h, powerState.(string))
+			if powerErr != nil {
+				return powerErr
+			}
+			d.Set("power_state", state)
+			d.SetPartial("power_state")
+		}
+	} // end HasChange("power_state")
+
 	// Use partial state mode in the event of failure of one of API calls required for host creation
 	d.Partial(false)
 
 	return nil
 }
 
-func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Tracef("resource_foreman_host.go#Delete")
-
-	client := meta.(*api.Client)
-	h := buildForemanHost(d)
-
-	log.Debugf("ForemanHost: [%+v]", h)
-	hostRetryCount := d.Get("retry_count").(int)
+// rebuildForemanHost finishes a rebuild of a host which was put into build
+// mode.  The host is optionally power cycled into PXE and the function
+// optionally waits for the rebuild to finish.
+func rebuildForemanHost(d *schema.ResourceData, client *api.Client, h *api.ForemanHost) error {
+	log.Tracef("resource_foreman_host.go#rebuildForemanHost")
 
-	if len(h.InterfacesAttributes) > 0 {
-		log.Debugf("deleting host that has interfaces set")
-		// iterate through each of the host interfaces and tag them for
-		// removal from the list
-		for idx := range h.InterfacesAttributes {
-			h.InterfacesAttributes[idx].Destroy = true
+	if d.Get("rebuild_power_cycle").(bool) {
+		var powerCmds []interface{}
+		if d.Get("enable_bmc").(bool) {
+			powerCmds = append(powerCmds, api.BMCBoot{
+				Device: api.BootPxe,
+			})
 		}
-		log.Debugf("host: [%+v]", h)
+		powerCmds = append(powerCmds, api.Power{
+			PowerAction: api.PowerCycle,
+		})
 
-		updatedHost, updateErr := client.UpdateHost(h, hostRetryCount)
-		if updateErr != nil {
-			return updateErr
+		for _, cmd := range powerCmds {
+			sendErr := client.SendPowerCommand(h, cmd)
+			if sendErr != nil {
+				return sendErr
+			}
+			// Sleep for 3 seconds between chained BMC calls
+			duration := time.Duration(3) * time.Second
+			time.Sleep(duration)
 		}
+	}
 
-		log.Debugf("updated Host: [%+v]", updatedHost)
+	if d.Get("wait_for_build").(bool) {
+		return waitForHostBuild(client, h.Id, d.Timeout(schema.TimeoutUpdate))
+	}
+	return nil
+}
 
-		// NOTE(ALL): set the resource data's properties to what comes back from
-		//   the update call. This allows us to recover from a partial state if
-		//   delete encounters an error after this point - at least the resource's
-		//   state will be saved with the correct interfaces.
-		setResourceDataFromForemanHost(d, updatedHost)
+func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_host.go#Delete")
 
-		log.Debugf("completed the interface deletion")
+	client := meta.(*api.Client)
+	h := buildForemanHost(d)
 
-	} // end if len(host.InterfacesAttributes) > 0
+	log.Debugf("ForemanHost: [%+v]", h)
+	hostRetryCount := d.Get("retry_count").(int)
 
+	// NOTE(ALL): The host's interfaces are not marked "_destroy" before the
+	//   host is deleted.  UpdateHost sends the interfaces to Foreman, which
+	//   refuses to remove the primary and provision interface of an existing
+	//   host and the delete would fail.  Deleting the host removes its
+	//   interfaces, so retry_count only waits for the host itself to be gone.
+	//
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
 	returnDelete := client.DeleteHost(h.Id)
@@ -907,12 +1488,14 @@ func resourceForemanHostDelete(d *schema.ResourceData, meta interface{}) error {
 	retry := 0
 	for retry < hostRetryCount {
 		log.Debugf("ForemanHostDelete: Waiting for deletion #[%d]", retry)
-		_, deleting := client.ReadHost(h.Id)
-		if deleting == nil {
+		_, readErr := client.ReadHost(h.Id)
+		if readErr == nil {
 			retry++
 			time.Sleep(2 * time.Second)
-		} else {
+		} else if api.IsNotFound(readErr) {
 			return nil
+		} else {
+			return readErr
 		}
 	}
 	return fmt.Errorf("Failed to delete host in retry_count* 2 seconds")
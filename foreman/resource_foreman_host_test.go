@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
@@ -30,6 +31,10 @@ func ForemanHostToInstanceState(obj api.ForemanHost) *terraform.InstanceState {
 	// Build the attribute map from ForemanHost
 	attr := map[string]string{}
 	attr["name"] = obj.Name
+	attr["fqdn"] = obj.Name
+	if obj.DomainName != "" {
+		attr["fqdn"] = obj.Name + "." + obj.DomainName
+	}
 	attr["domain_id"] = strconv.Itoa(obj.DomainId)
 	attr["environment_id"] = strconv.Itoa(obj.EnvironmentId)
 	attr["hostgroup_id"] = strconv.Itoa(obj.HostgroupId)
@@ -201,6 +206,187 @@ func TestHostUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal only strips the domain from the end of the name
+// and reads the provision method
+func TestHostUnmarshalJSON_NameAndMethod(t *testing.T) {
+
+	testCases := []struct {
+		json           string
+		expectedName   string
+		expectedFQDN   string
+		expectedMethod string
+	}{
+		{
+			`{"name":"web01.example.com","domain_name":"example.com","provision_method":"image"}`,
+			"web01",
+			"web01.example.com",
+			"image",
+		},
+		{
+			`{"name":"web01.example.com.lab","domain_name":"example.com"}`,
+			"web01.example.com.lab",
+			"web01.example.com.lab.example.com",
+			"build",
+		},
+		{
+			`{"name":"web01","domain_name":""}`,
+			"web01",
+			"web01",
+			"build",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var obj api.ForemanHost
+		jsonDecErr := json.Unmarshal([]byte(testCase.json), &obj)
+		if jsonDecErr != nil {
+			t.Fatalf(
+				"ForemanHost UnmarshalJSON returned an error for [%s]: [%s]",
+				testCase.json,
+				jsonDecErr,
+			)
+		}
+		if obj.Name != testCase.expectedName {
+			t.Errorf(
+				"ForemanHost UnmarshalJSON did not set the name for [%s]. "+
+					"Expected [%s], got [%s]",
+				testCase.json,
+				testCase.expectedName,
+				obj.Name,
+			)
+		}
+		if fqdn := foremanHostFQDN(&obj); fqdn != testCase.expectedFQDN {
+			t.Errorf(
+				"foremanHostFQDN did not return the FQDN for [%s]. "+
+					"Expected [%s], got [%s]",
+				testCase.json,
+				testCase.expectedFQDN,
+				fqdn,
+			)
+		}
+		if obj.Method != testCase.expectedMethod {
+			t.Errorf(
+				"ForemanHost UnmarshalJSON did not set the method for [%s]. "+
+					"Expected [%s], got [%s]",
+				testCase.json,
+				testCase.expectedMethod,
+				obj.Method,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanHostImport
+// -----------------------------------------------------------------------------
+
+// Ensures a host is imported by its ID as is and by its FQDN through a search
+// by name.  The provisioning attributes are set to their defaults.
+func TestResourceForemanHostImport(t *testing.T) {
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	searches := 0
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		search := r.URL.Query().Get("search")
+		if search != `name="web01.example.com"` {
+			w.Write([]byte(`{"total": 2, "subtotal": 0, "page": 1, "per_page": 20, "results": []}`))
+			return
+		}
+		w.Write([]byte(`{
+			"total": 2, "subtotal": 1, "page": 1, "per_page": 20,
+			"results": [{"id": 42, "name": "web01.example.com", "domain_name": "example.com"}]
+		}`))
+	})
+
+	// import by ID
+	rd := resourceForemanHost().Data(nil)
+	rd.SetId("42")
+	rds, importErr := resourceForemanHostImport(rd, client)
+	if importErr != nil {
+		t.Fatalf("resourceForemanHostImport returned an error: [%s]", importErr)
+	}
+	if len(rds) != 1 || rds[0].Id() != "42" || searches != 0 {
+		t.Fatalf(
+			"resourceForemanHostImport did not import the host by its ID. "+
+				"Got [%d] resources with ID [%s] after [%d] searches",
+			len(rds),
+			rds[0].Id(),
+			searches,
+		)
+	}
+
+	// import by FQDN
+	rd = resourceForemanHost().Data(nil)
+	rd.SetId("web01.example.com")
+	rds, importErr = resourceForemanHostImport(rd, client)
+	if importErr != nil {
+		t.Fatalf("resourceForemanHostImport returned an error: [%s]", importErr)
+	}
+	if len(rds) != 1 || rds[0].Id() != "42" {
+		t.Fatalf(
+			"resourceForemanHostImport did not resolve the FQDN. Expected ID "+
+				"[42], got [%s]",
+			rds[0].Id(),
+		)
+	}
+	if rds[0].Get("retry_count").(int) != 2 || !rds[0].Get("bmc_success").(bool) {
+		t.Errorf(
+			"resourceForemanHostImport did not set the defaults. Got "+
+				"retry_count [%d], bmc_success [%t]",
+			rds[0].Get("retry_count").(int),
+			rds[0].Get("bmc_success").(bool),
+		)
+	}
+
+	// import by an unknown FQDN
+	rd = resourceForemanHost().Data(nil)
+	rd.SetId("web02.example.com")
+	if _, importErr = resourceForemanHostImport(rd, client); importErr == nil {
+		t.Errorf("resourceForemanHostImport did not fail for an unknown FQDN")
+	}
+
+}
+
+// Ensures a configured FQDN is the same name as the short name read back from
+// Foreman, while a different name still causes a diff
+func TestSuppressForemanHostFQDNName(t *testing.T) {
+
+	s := ForemanHostToInstanceState(api.ForemanHost{
+		ForemanObject: api.ForemanObject{Id: 42, Name: "web01"},
+		DomainName:    "example.com",
+	})
+	rd := MockForemanHostResourceData(s)
+
+	testCases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"web01", "web01.example.com", true},
+		{"web01", "web02.example.com", false},
+		{"web01", "web01.example.org", false},
+		{"", "web01.example.com", false},
+	}
+
+	for _, testCase := range testCases {
+		if suppressForemanHostFQDNName("name", testCase.old, testCase.new, rd) != testCase.expected {
+			t.Errorf(
+				"suppressForemanHostFQDNName(%q, %q) did not return [%t]",
+				testCase.old,
+				testCase.new,
+				testCase.expected,
+			)
+		}
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // buildForemanHost
 // -----------------------------------------------------------------------------
@@ -243,6 +429,527 @@ func TestSetResourceDataFromForemanHost_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// waitForHostBuild
+// -----------------------------------------------------------------------------
+
+// Ensures waitForHostBuild polls the host until it is built and fails on a
+// failed build or a timeout
+func TestWaitForHostBuild(t *testing.T) {
+	hostBuildPollInterval = time.Millisecond
+
+	building := `{"id":1,"name":"host01","build":true,"build_status":1,` +
+		`"build_status_label":"Pending installation","installed_at":null}`
+	built := `{"id":1,"name":"host01","build":false,"build_status":0,` +
+		`"build_status_label":"Installed","installed_at":"2020-01-01 00:00:00 UTC"}`
+	failed := `{"id":1,"name":"host01","build":true,"build_status":3,` +
+		`"build_status_label":"Installation error",` +
+		`"last_report":"2020-01-01 00:00:00 UTC"}`
+
+	testCases := []struct {
+		name      string
+		responses []string
+		timeout   time.Duration
+		expectErr bool
+	}{
+		{"built", []string{building, building, built}, time.Minute, false},
+		{"failed", []string{building, failed}, time.Minute, true},
+		{"timeout", []string{building}, 50 * time.Millisecond, true},
+	}
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(cred, conf)
+
+		reads := 0
+		responses := testCase.responses
+		mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+			// NOTE(ALL): the last response is repeated once all were sent
+			resp := responses[len(responses)-1]
+			if reads < len(responses) {
+				resp = responses[reads]
+			}
+			reads++
+			fmt.Fprint(w, resp)
+		})
+
+		waitErr := waitForHostBuild(client, 1, testCase.timeout)
+		server.Close()
+
+		if (waitErr != nil) != testCase.expectErr {
+			t.Errorf(
+				"waitForHostBuild() returned an unexpected result for case [%s]. "+
+					"Expected error [%t], got [%v]",
+				testCase.name,
+				testCase.expectErr,
+				waitErr,
+			)
+		}
+		if !testCase.expectErr && reads != len(responses) {
+			t.Errorf(
+				"waitForHostBuild() did not stop polling once the host was built. "+
+					"Expected [%d] reads, got [%d]",
+				len(responses),
+				reads,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// convergeHostPowerState
+// -----------------------------------------------------------------------------
+
+// Ensures convergeHostPowerState only sends a power action when the actual
+// power state differs from the desired state
+func TestConvergeHostPowerState(t *testing.T) {
+	testCases := []struct {
+		actual         string
+		desired        string
+		expectedAction string
+	}{
+		{api.PowerOff, api.PowerOn, api.PowerOn},
+		{api.PowerOn, api.PowerOff, api.PowerOff},
+		{api.PowerOn, api.PowerOn, ""},
+		// states reported by compute resources for VMs
+		{"running", api.PowerOn, ""},
+		{"poweredOn", api.PowerOff, api.PowerOff},
+		{"poweredOff", api.PowerOn, api.PowerOn},
+		{"stopped", api.PowerOff, ""},
+	}
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(cred, conf)
+
+		sentAction := ""
+		actual := testCase.actual
+		mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			json.NewDecoder(r.Body).Decode(&power)
+			if power.PowerAction == api.PowerState {
+				fmt.Fprintf(w, `{"power":"%s"}`, actual)
+				return
+			}
+			sentAction = power.PowerAction
+			fmt.Fprint(w, `{"power":true}`)
+		})
+
+		h := api.ForemanHost{}
+		h.Id = 1
+		state, powerErr := convergeHostPowerState(client, &h, testCase.desired)
+		server.Close()
+
+		if powerErr != nil || state != testCase.desired {
+			t.Errorf(
+				"convergeHostPowerState() did not converge the power state. "+
+					"Expected [%s, nil], got [%s, %v]",
+				testCase.desired,
+				state,
+				powerErr,
+			)
+		}
+		if sentAction != testCase.expectedAction {
+			t.Errorf(
+				"convergeHostPowerState() sent the wrong power action for actual "+
+					"state [%s] and desired state [%s]. Expected [%s], got [%s]",
+				testCase.actual,
+				testCase.desired,
+				testCase.expectedAction,
+				sentAction,
+			)
+		}
+	}
+}
+
+// Ensures a power state which cannot be read does not fail the refresh of the
+// host and the previous power state is kept
+func TestResourceForemanHostRead_PowerStateError(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"name":"host01"}`)
+	})
+	mux.HandleFunc(HostsURI+"/1/power", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"power":false}`)
+	})
+
+	obj := api.ForemanHost{}
+	obj.Id = 1
+	obj.Name = "host01"
+	s := ForemanHostToInstanceState(obj)
+	s.Attributes["power_state"] = api.PowerOn
+	rd := MockForemanHostResourceData(s)
+
+	if readErr := resourceForemanHostRead(rd, client); readErr != nil {
+		t.Fatalf("resourceForemanHostRead() returned an error: [%v]", readErr)
+	}
+	if state := rd.Get("power_state").(string); state != api.PowerOn {
+		t.Errorf(
+			"resourceForemanHostRead() did not keep the previous power state. "+
+				"Expected [%s], got [%s]",
+			api.PowerOn,
+			state,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// rebuild_trigger
+// -----------------------------------------------------------------------------
+
+// Ensures a change to rebuild_trigger puts the existing host back into build
+// mode through an update instead of re-creating it
+func TestResourceForemanHostUpdate_RebuildTrigger(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	updates := 0
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Unexpected request [%s %s]", r.Method, r.URL)
+			return
+		}
+		updates++
+		var reqData map[string]api.ForemanHost
+		json.NewDecoder(r.Body).Decode(&reqData)
+		if !reqData["host"].Build {
+			t.Errorf("Rebuild did not set the build flag of the host")
+		}
+		fmt.Fprint(w, `{"id":1,"name":"host01","build":true}`)
+	})
+
+	obj := api.ForemanHost{}
+	obj.Id = 1
+	obj.Name = "host01"
+	s := ForemanHostToInstanceState(obj)
+	// NOTE(ALL): no pending BMC operations
+	s.Attributes["bmc_success"] = "true"
+
+	// NOTE(ALL): HasChange() only detects changes from a diff, build the
+	//   resource data from the state and a diff against the new config
+	r := resourceForemanHost()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "host01",
+		"rebuild_trigger": map[string]interface{}{
+			"image_version": "2020.1",
+		},
+	})
+	diff, _ := r.Diff(s, config, nil)
+	rd, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+	if updateErr := resourceForemanHostUpdate(rd, client); updateErr != nil {
+		t.Fatalf("resourceForemanHostUpdate() returned an error: [%v]", updateErr)
+	}
+	if updates != 1 || rd.Id() != "1" {
+		t.Errorf(
+			"Rebuild did not update the existing host. Expected [1] update of "+
+				"host [1], got [%d] updates of host [%s]",
+			updates,
+			rd.Id(),
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// CustomizeDiff
+// -----------------------------------------------------------------------------
+
+// Ensures only MAC addresses Foreman accepts pass validation
+func TestValidateMAC(t *testing.T) {
+	testCases := []struct {
+		mac       string
+		expectErr bool
+	}{
+		{"c0:ff:ee:ba:be:00", false},
+		{"C0-FF-EE-BA-BE-00", false},
+		{"", false},
+		{"c0:ff:ee:ba:be", true},
+		{"not-a-mac", true},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validateMAC(testCase.mac, "mac")
+		if (len(errs) > 0) != testCase.expectErr {
+			t.Errorf(
+				"validateMAC() returned an unexpected result for [%s]. "+
+					"Expected error [%t], got [%v]",
+				testCase.mac,
+				testCase.expectErr,
+				errs,
+			)
+		}
+	}
+}
+
+// Ensures a host needs exactly one primary and one provision interface and
+// its interfaces do not share IP or MAC addresses
+func TestValidateForemanHostInterfaces(t *testing.T) {
+	primary := api.ForemanInterfacesAttribute{
+		Primary:   true,
+		Provision: true,
+		IP:        "10.228.247.10",
+		MAC:       "c0:ff:ee:ba:be:00",
+	}
+	secondary := api.ForemanInterfacesAttribute{
+		IP:  "10.228.247.11",
+		MAC: "c0:ff:ee:ba:be:01",
+	}
+	vlan := api.ForemanInterfacesAttribute{
+		IP:      "10.228.247.12",
+		MAC:     "C0:FF:EE:BA:BE:00",
+		Virtual: true,
+	}
+	duplicateIP := secondary
+	duplicateIP.IP = "10.228.247.10"
+	duplicateMAC := secondary
+	duplicateMAC.MAC = "C0:FF:EE:BA:BE:00"
+	secondPrimary := secondary
+	secondPrimary.Primary = true
+	provisionOnly := primary
+	provisionOnly.Primary = false
+
+	testCases := []struct {
+		name       string
+		interfaces []api.ForemanInterfacesAttribute
+		expectErr  bool
+	}{
+		{"valid", []api.ForemanInterfacesAttribute{primary, secondary}, false},
+		{"virtual shares mac", []api.ForemanInterfacesAttribute{primary, vlan}, false},
+		{"duplicate ip", []api.ForemanInterfacesAttribute{primary, duplicateIP}, true},
+		{"duplicate mac", []api.ForemanInterfacesAttribute{primary, duplicateMAC}, true},
+		{"two primary", []api.ForemanInterfacesAttribute{primary, secondPrimary}, true},
+		{"no primary", []api.ForemanInterfacesAttribute{provisionOnly, secondary}, true},
+		{"no provision", []api.ForemanInterfacesAttribute{secondPrimary}, true},
+	}
+
+	for _, testCase := range testCases {
+		validateErr := validateForemanHostInterfaces(testCase.interfaces)
+		if (validateErr != nil) != testCase.expectErr {
+			t.Errorf(
+				"validateForemanHostInterfaces() returned an unexpected result for "+
+					"case [%s]. Expected error [%t], got [%v]",
+				testCase.name,
+				testCase.expectErr,
+				validateErr,
+			)
+		}
+	}
+}
+
+// Ensures the plan fails when the IP address of an interface is outside its
+// subnet or outside the subnet's IPAM range
+func TestResourceForemanHostCustomizeDiff(t *testing.T) {
+	// NOTE(ALL): value terraform uses for attributes which are not known
+	//   until apply
+	const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	// NOTE(ALL): network 10.228.247.0/24 with the IPAM range
+	//   10.228.247.1 - 10.228.247.199
+	subnetReads := 0
+	mux.HandleFunc(SubnetsURI+"/352", func(w http.ResponseWriter, r *http.Request) {
+		subnetReads++
+		http.ServeFile(w, r, SubnetsTestDataPath+"/read_response.json")
+	})
+
+	testCases := []struct {
+		name      string
+		ips       []string
+		expectErr bool
+	}{
+		{"within range", []string{"10.228.247.10", "10.228.247.11"}, false},
+		{"outside network", []string{"10.228.247.10", "10.228.248.10"}, true},
+		{"outside ipam range", []string{"10.228.247.10", "10.228.247.200"}, true},
+		{"unknown ip", []string{"10.228.247.10", unknownValue}, false},
+	}
+
+	r := resourceForemanHost()
+	for _, testCase := range testCases {
+		subnetReads = 0
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "host01",
+			"interfaces_attributes": []interface{}{
+				map[string]interface{}{
+					"primary":   true,
+					"provision": true,
+					"ip":        testCase.ips[0],
+					"mac":       "c0:ff:ee:ba:be:00",
+					"subnet_id": 352,
+				},
+				map[string]interface{}{
+					"ip":        testCase.ips[1],
+					"mac":       "c0:ff:ee:ba:be:01",
+					"subnet_id": 352,
+				},
+			},
+		})
+
+		_, diffErr := r.Diff(nil, config, client)
+		if (diffErr != nil) != testCase.expectErr {
+			t.Errorf(
+				"CustomizeDiff returned an unexpected result for case [%s]. "+
+					"Expected error [%t], got [%v]",
+				testCase.name,
+				testCase.expectErr,
+				diffErr,
+			)
+		}
+		if subnetReads == 0 {
+			t.Errorf(
+				"CustomizeDiff did not read the subnet of the interfaces for "+
+					"case [%s]",
+				testCase.name,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// interfaces_attributes
+// -----------------------------------------------------------------------------
+
+// Ensures interfaces are matched by identifier or ID, keep the ID of the
+// existing interface and removed interfaces are tagged for destruction
+func TestMergeForemanInterfacesAttributes(t *testing.T) {
+	oldList := []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "ens192", IP: "10.228.247.10", Primary: true},
+		{Id: 2, Identifier: "ens224", IP: "10.228.248.10"},
+		{Id: 3, IP: "10.228.249.10"},
+	}
+	newList := []api.ForemanInterfacesAttribute{
+		{Identifier: "ens192", IP: "10.228.247.11", Primary: true},
+		{Id: 3, IP: "10.228.249.11"},
+		{Identifier: "ens256", IP: "10.228.250.10"},
+	}
+
+	expected := []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "ens192", IP: "10.228.247.11", Primary: true},
+		{Id: 3, IP: "10.228.249.11"},
+		{Identifier: "ens256", IP: "10.228.250.10"},
+		{Id: 2, Identifier: "ens224", IP: "10.228.248.10", Destroy: true},
+	}
+
+	merged := mergeForemanInterfacesAttributes(oldList, newList)
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf(
+			"mergeForemanInterfacesAttributes() did not merge the interfaces. "+
+				"Expected [%+v], got [%+v]",
+			expected,
+			merged,
+		)
+	}
+}
+
+// Ensures interfaces with an identifier keep their set hash when any other
+// attribute changes
+func TestHashForemanInterfacesAttribute(t *testing.T) {
+	iface := map[string]interface{}{
+		"identifier": "ens192",
+		"ip":         "10.228.247.10",
+	}
+	changedIface := map[string]interface{}{
+		"identifier": "ens192",
+		"ip":         "10.228.247.11",
+	}
+	if hashForemanInterfacesAttribute(iface) != hashForemanInterfacesAttribute(changedIface) {
+		t.Errorf("Changing the IP of an interface changed its hash")
+	}
+
+	delete(iface, "identifier")
+	delete(changedIface, "identifier")
+	if hashForemanInterfacesAttribute(iface) == hashForemanInterfacesAttribute(changedIface) {
+		t.Errorf("Interfaces without an identifier are not hashed by their attributes")
+	}
+}
+
+// Ensures a change of the interfaces updates the existing host and sends the
+// changed and removed interfaces
+func TestResourceForemanHostUpdate_Interfaces(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var sentInterfaces []api.ForemanInterfacesAttribute
+	mux.HandleFunc(HostsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Unexpected request [%s %s]", r.Method, r.URL)
+			return
+		}
+		var reqData map[string]map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&reqData)
+		json.Unmarshal(reqData["host"]["interfaces_attributes"], &sentInterfaces)
+		fmt.Fprint(w, `{"id":1,"name":"host01","interfaces":[`+
+			`{"id":11,"identifier":"ens192","ip":"10.228.247.11","primary":true,"provision":true}]}`)
+	})
+
+	obj := api.ForemanHost{}
+	obj.Id = 1
+	obj.Name = "host01"
+	s := ForemanHostToInstanceState(obj)
+	s.Attributes["bmc_success"] = "true"
+	s.Attributes["method"] = "build"
+
+	r := resourceForemanHost()
+	state := r.Data(s)
+	setResourceDataFromForemanInterfacesAttributes(state, []api.ForemanInterfacesAttribute{
+		{Id: 11, Identifier: "ens192", IP: "10.228.247.10", Primary: true, Provision: true},
+		{Id: 12, Identifier: "ens224", IP: "10.228.248.10"},
+	})
+	s = state.State()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "host01",
+		"interfaces_attributes": []interface{}{
+			map[string]interface{}{
+				"identifier": "ens192",
+				"ip":         "10.228.247.11",
+				"primary":    true,
+				"provision":  true,
+			},
+		},
+	})
+	diff, diffErr := r.Diff(s, config, client)
+	if diffErr != nil {
+		t.Fatalf("Diff() returned an error: [%v]", diffErr)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Changing the interfaces of a host requires a new host")
+	}
+	rd, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+	if updateErr := resourceForemanHostUpdate(rd, client); updateErr != nil {
+		t.Fatalf("resourceForemanHostUpdate() returned an error: [%v]", updateErr)
+	}
+
+	expected := []api.ForemanInterfacesAttribute{
+		{Id: 11, Identifier: "ens192", IP: "10.228.247.11", Primary: true, Provision: true},
+		{Id: 12, Identifier: "ens224", IP: "10.228.248.10", Destroy: true},
+	}
+	if !reflect.DeepEqual(sentInterfaces, expected) {
+		t.Errorf(
+			"resourceForemanHostUpdate() sent unexpected interfaces. "+
+				"Expected [%+v], got [%+v]",
+			expected,
+			sentInterfaces,
+		)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -325,18 +1032,19 @@ func ResourceForemanHostRequestDataTestCases(t *testing.T) []TestCaseRequestData
 	s := ForemanHostToInstanceState(obj)
 
 	rd := MockForemanHostResourceData(s)
+	rd.Set("method", "build")
 	obj = *buildForemanHost(rd)
 	// NOTE(ALL): See note in Create and Update functions for build flag
 	//   override
 	obj.Build = true
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("host", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
 			TestCase: TestCase{
 				funcName:     "resourceForemanHostCreate",
 				crudFunc:     resourceForemanHostCreate,
-				resourceData: MockForemanHostResourceData(s),
+				resourceData: rd,
 			},
 			expectedData: reqData,
 		},
@@ -24,6 +24,8 @@ func resourceForemanHostgroup() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffForemanParameters,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -94,12 +96,14 @@ func resourceForemanHostgroup() *schema.Resource {
 					"\"iPXE Chain UEFI\"",
 			},
 			"parameters": &schema.Schema{
-				Type:     schema.TypeMap,
-				ForceNew: false,
-				Optional: true,
+				Type:          schema.TypeMap,
+				ForceNew:      false,
+				Optional:      true,
+				ConflictsWith: []string{"parameter"},
 				Description: "A map of parameters that will be saved as hostgroup parameters " +
 					"in the group config.",
 			},
+			"parameter": foremanParameterSchema("hostgroup"),
 
 			// -- Foreign Key Relationships --
 
@@ -199,6 +203,14 @@ func resourceForemanHostgroup() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the subnet associated with the hostgroup.",
 			},
+
+			"organization_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the organization the hostgroup belongs to.",
+			},
 		},
 	}
 }
@@ -281,15 +293,10 @@ func buildForemanHostgroup(d *schema.ResourceData) *api.ForemanHostgroup {
 	if attr, ok = d.GetOk("subnet_id"); ok {
 		hostgroup.SubnetId = attr.(int)
 	}
-	if attr, ok = d.GetOk("parameters"); ok {
-		hostTags := d.Get("parameters").(map[string]interface{})
-		for key, value := range hostTags {
-			hostgroup.HostGroupParameters = append(hostgroup.HostGroupParameters, api.ForemanKVParameter{
-				Name:  key,
-				Value: value.(string),
-			})
-		}
+	if attr, ok = d.GetOk("organization_id"); ok {
+		hostgroup.OrganizationId = attr.(int)
 	}
+	hostgroup.HostGroupParameters = buildForemanKVParameters(d)
 
 	return &hostgroup
 }
@@ -303,7 +310,7 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("title", fh.Title)
 	d.Set("name", fh.Name)
 	d.Set("pxe_loader", fh.PXELoader)
-	d.Set("parameters", fh.HostGroupParameters)
+	setForemanKVParameters(d, fh.HostGroupParameters)
 	d.Set("architecture_id", fh.ArchitectureId)
 	d.Set("compute_profile_id", fh.ComputeProfileId)
 	d.Set("domain_id", fh.DomainId)
@@ -316,6 +323,7 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("puppet_proxy_id", fh.PuppetProxyId)
 	d.Set("realm_id", fh.RealmId)
 	d.Set("subnet_id", fh.SubnetId)
+	d.Set("organization_id", fh.OrganizationId)
 }
 
 // -----------------------------------------------------------------------------
@@ -352,7 +360,7 @@ func resourceForemanHostgroupRead(d *schema.ResourceData, meta interface{}) erro
 
 	readHostgroup, readErr := client.ReadHostgroup(h.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanHostgroup: [%+v]", readHostgroup)
@@ -375,6 +383,19 @@ func resourceForemanHostgroupUpdate(d *schema.ResourceData, meta interface{}) er
 
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
+	// NOTE(ALL): Parameters are only sent to Foreman when they changed.  The
+	//   IDs of the existing parameters are read from Foreman to update and
+	//   remove them.
+	if d.HasChange("parameters") || d.HasChange("parameter") {
+		currentHostgroup, readErr := client.ReadHostgroup(h.Id)
+		if readErr != nil {
+			return readErr
+		}
+		h.HostGroupParameters = reconcileForemanKVParameters(currentHostgroup.HostGroupParameters, h.HostGroupParameters)
+	} else {
+		h.HostGroupParameters = nil
+	}
+
 	updatedHostgroup, updateErr := client.UpdateHostgroup(h)
 	if updateErr != nil {
 		return updateErr
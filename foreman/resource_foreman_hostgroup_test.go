@@ -34,7 +34,7 @@ func ForemanHostgroupToInstanceState(obj api.ForemanHostgroup) *terraform.Instan
 	attr["compute_profile_id"] = strconv.Itoa(obj.ComputeProfileId)
 	attr["domain_id"] = strconv.Itoa(obj.DomainId)
 	attr["environment_id"] = strconv.Itoa(obj.EnvironmentId)
-	attr["medium_id"] = strconv.Itoa(obj.MediaId)
+	attr["medium_id"] = strconv.Itoa(obj.MediumId)
 	attr["operatingsystem_id"] = strconv.Itoa(obj.OperatingSystemId)
 	attr["parent_id"] = strconv.Itoa(obj.ParentId)
 	attr["ptable_id"] = strconv.Itoa(obj.PartitionTableId)
@@ -74,7 +74,7 @@ func RandForemanHostgroup() api.ForemanHostgroup {
 	obj.ComputeProfileId = rand.Intn(100)
 	obj.DomainId = rand.Intn(100)
 	obj.EnvironmentId = rand.Intn(100)
-	obj.MediaId = rand.Intn(100)
+	obj.MediumId = rand.Intn(100)
 	obj.OperatingSystemId = rand.Intn(100)
 	obj.ParentId = rand.Intn(100)
 	obj.PartitionTableId = rand.Intn(100)
@@ -269,7 +269,7 @@ func ResourceForemanHostgroupRequestDataTestCases(t *testing.T) []TestCaseReques
 
 	rd := MockForemanHostgroupResourceData(s)
 	obj = *buildForemanHostgroup(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("hostgroup", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
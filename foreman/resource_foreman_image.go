@@ -141,7 +141,7 @@ func resourceForemanImageRead(d *schema.ResourceData, meta interface{}) error {
 
 	readImage, readErr := client.ReadImage(image)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanImage: [%+v]", readImage)
@@ -39,7 +39,8 @@ func resourceForemanLocation() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				Description: fmt.Sprintf(
-					"Name of the location",
+					"Name of the location. "+
+						"%s \"Berlin\"",
 					autodoc.MetaExample,
 				),
 			},
@@ -251,7 +252,7 @@ func resourceForemanLocationRead(d *schema.ResourceData, meta interface{}) error
 
 	readLocation, readErr := client.ReadLocation(e.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanLocation: [%+v]", readLocation)
@@ -181,7 +181,7 @@ func resourceForemanMediaRead(d *schema.ResourceData, meta interface{}) error {
 
 	readMedia, readErr := client.ReadMedia(m.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanMedia: [%+v]", readMedia)
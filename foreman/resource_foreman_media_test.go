@@ -279,7 +279,7 @@ func ResourceForemanMediaRequestDataTestCases(t *testing.T) []TestCaseRequestDat
 
 	rd := MockForemanMediaResourceData(s)
 	obj = *buildForemanMedia(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("medium", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
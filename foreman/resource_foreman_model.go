@@ -145,7 +145,7 @@ func resourceForemanModelRead(d *schema.ResourceData, meta interface{}) error {
 
 	readModel, readErr := client.ReadModel(m.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanModel: [%+v]", readModel)
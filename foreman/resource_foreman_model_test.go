@@ -249,7 +249,7 @@ func ResourceForemanModelRequestDataTestCases(t *testing.T) []TestCaseRequestDat
 
 	rd := MockForemanModelResourceData(s)
 	obj = *buildForemanModel(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("model", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
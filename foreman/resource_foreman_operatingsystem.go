@@ -25,6 +25,8 @@ func resourceForemanOperatingSystem() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffForemanParameters,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -165,12 +167,14 @@ func resourceForemanOperatingSystem() *schema.Resource {
 				Description: "Identifiers of attached partition tables",
 			},
 			"parameters": &schema.Schema{
-				Type:     schema.TypeMap,
-				ForceNew: false,
-				Optional: true,
+				Type:          schema.TypeMap,
+				ForceNew:      false,
+				Optional:      true,
+				ConflictsWith: []string{"parameter"},
 				Description: "A map of parameters that will be saved as operating system parameters " +
 					"in the os config.",
 			},
+			"parameter": foremanParameterSchema("operating system"),
 		},
 	}
 }
@@ -231,15 +235,7 @@ func buildForemanOperatingSystem(d *schema.ResourceData) *api.ForemanOperatingSy
 		attrSet := attr.(*schema.Set)
 		os.PartitiontableIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
-	if attr, ok = d.GetOk("parameters"); ok {
-		hostTags := d.Get("parameters").(map[string]interface{})
-		for key, value := range hostTags {
-			os.OperatingSystemParameters = append(os.OperatingSystemParameters, api.ForemanKVParameter{
-				Name:  key,
-				Value: value.(string),
-			})
-		}
-	}
+	os.OperatingSystemParameters = buildForemanKVParameters(d)
 
 	return &os
 }
@@ -262,7 +258,7 @@ func setResourceDataFromForemanOperatingSystem(d *schema.ResourceData, fo *api.F
 	d.Set("media", fo.MediumIds)
 	d.Set("architectures", fo.ArchitectureIds)
 	d.Set("partitiontables", fo.PartitiontableIds)
-	d.Set("parameters", fo.OperatingSystemParameters)
+	setForemanKVParameters(d, fo.OperatingSystemParameters)
 }
 
 // -----------------------------------------------------------------------------
@@ -297,7 +293,7 @@ func resourceForemanOperatingSystemRead(d *schema.ResourceData, meta interface{}
 
 	readOS, readErr := client.ReadOperatingSystem(o.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", readOS)
@@ -315,6 +311,19 @@ func resourceForemanOperatingSystemUpdate(d *schema.ResourceData, meta interface
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", o)
 
+	// NOTE(ALL): Parameters are only sent to Foreman when they changed.  The
+	//   IDs of the existing parameters are read from Foreman to update and
+	//   remove them.
+	if d.HasChange("parameters") || d.HasChange("parameter") {
+		currentOs, readErr := client.ReadOperatingSystem(o.Id)
+		if readErr != nil {
+			return readErr
+		}
+		o.OperatingSystemParameters = reconcileForemanKVParameters(currentOs.OperatingSystemParameters, o.OperatingSystemParameters)
+	} else {
+		o.OperatingSystemParameters = nil
+	}
+
 	updatedOs, updateErr := client.UpdateOperatingSystem(o)
 	if updateErr != nil {
 		return updateErr
@@ -0,0 +1,323 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanOrganization() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanOrganizationCreate,
+		Read:   resourceForemanOrganizationRead,
+		Update: resourceForemanOrganizationUpdate,
+		Delete: resourceForemanOrganizationDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s An organization. Organizations are organized in a tree-like "+
+						"structure and, together with locations, separate the objects "+
+						"of different tenants.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The title is the fullname of the organization.  An " +
+					"organization's title is a path-like string from the head " +
+					"of the organization tree down to this organization.  The title " +
+					"will be in the form of: \"<parent 1>/<parent 2>/.../<name>\".",
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the organization. "+
+						"%s \"ACME\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the organization.",
+			},
+
+			"parameters": &schema.Schema{
+				Type:     schema.TypeMap,
+				ForceNew: false,
+				Optional: true,
+				Description: "A map of parameters that will be saved as organization " +
+					"parameters in the organization config.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"parent_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of this organization's parent organization.",
+			},
+			"realm_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the realms assigned to the organization.",
+			},
+			"compute_resource_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the compute resources assigned to the organization.",
+			},
+			"domain_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the domains assigned to the organization.",
+			},
+			"subnet_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the subnets assigned to the organization.",
+			},
+			"environment_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the environments assigned to the organization.",
+			},
+			"hostgroup_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the hostgroups assigned to the organization.",
+			},
+			"provisioning_template_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the provisioning templates assigned to the organization.",
+			},
+			"smart_proxy_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the smart proxies assigned to the organization.",
+			},
+			"user_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the users assigned to the organization.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanOrganization constructs a ForemanOrganization reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanOrganization(d *schema.ResourceData) *api.ForemanOrganization {
+	log.Tracef("resource_foreman_organization.go#buildForemanOrganization")
+
+	organization := api.ForemanOrganization{}
+
+	obj := buildForemanObject(d)
+	organization.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("title"); ok {
+		organization.Title = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("description"); ok {
+		organization.Description = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("parent_id"); ok {
+		organization.ParentId = attr.(int)
+	}
+
+	organization.Realms = buildForemanIds(d, "realm_ids")
+	organization.ComputeResources = buildForemanIds(d, "compute_resource_ids")
+	organization.Domains = buildForemanIds(d, "domain_ids")
+	organization.Subnets = buildForemanIds(d, "subnet_ids")
+	organization.Environments = buildForemanIds(d, "environment_ids")
+	organization.Hostgroups = buildForemanIds(d, "hostgroup_ids")
+	organization.ProvisioningTemplates = buildForemanIds(d, "provisioning_template_ids")
+	organization.SmartProxies = buildForemanIds(d, "smart_proxy_ids")
+	organization.Users = buildForemanIds(d, "user_ids")
+
+	organization.OrganizationParameters = buildForemanKVParameters(d)
+
+	return &organization
+}
+
+// setResourceDataFromForemanOrganization sets a ResourceData's attributes from
+// the attributes of the supplied ForemanOrganization reference
+func setResourceDataFromForemanOrganization(d *schema.ResourceData, fo *api.ForemanOrganization) {
+	log.Tracef("resource_foreman_organization.go#setResourceDataFromForemanOrganization")
+
+	d.SetId(strconv.Itoa(fo.Id))
+	d.Set("title", fo.Title)
+	d.Set("name", fo.Name)
+	d.Set("description", fo.Description)
+	d.Set("parent_id", fo.ParentId)
+	d.Set("realm_ids", fo.Realms)
+	d.Set("compute_resource_ids", fo.ComputeResources)
+	d.Set("domain_ids", fo.Domains)
+	d.Set("subnet_ids", fo.Subnets)
+	d.Set("environment_ids", fo.Environments)
+	d.Set("hostgroup_ids", fo.Hostgroups)
+	d.Set("provisioning_template_ids", fo.ProvisioningTemplates)
+	d.Set("smart_proxy_ids", fo.SmartProxies)
+	d.Set("user_ids", fo.Users)
+
+	setForemanKVParameters(d, fo.OrganizationParameters)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanOrganizationCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_organization.go#Create")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	createdOrganization, createErr := client.CreateOrganization(o)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanOrganization: [%+v]", createdOrganization)
+
+	setResourceDataFromForemanOrganization(d, createdOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	readOrganization, readErr := client.ReadOrganization(o.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_organization.go#Update")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	// NOTE(ALL): Parameters are only sent to Foreman when they changed.  The
+	//   IDs of the existing parameters are read from Foreman to update and
+	//   remove them.
+	if d.HasChange("parameters") {
+		currentOrganization, readErr := client.ReadOrganization(o.Id)
+		if readErr != nil {
+			return readErr
+		}
+		o.OrganizationParameters = reconcileForemanKVParameters(currentOrganization.OrganizationParameters, o.OrganizationParameters)
+	} else {
+		o.OrganizationParameters = nil
+	}
+
+	updatedOrganization, updateErr := client.UpdateOrganization(o)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanOrganization: [%+v]", updatedOrganization)
+
+	setResourceDataFromForemanOrganization(d, updatedOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_organization.go#Delete")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteOrganization(o.Id)
+}
@@ -0,0 +1,523 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const OrganizationsURI = api.FOREMAN_API_URL_PREFIX + "/organizations"
+const OrganizationsTestDataPath = "testdata/1.11/organizations"
+
+// Given a ForemanOrganization, create a mock instance state reference
+func ForemanOrganizationToInstanceState(obj api.ForemanOrganization) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanOrganization
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["title"] = obj.Title
+	attr["description"] = obj.Description
+	attr["parent_id"] = strconv.Itoa(obj.ParentId)
+	attr["domain_ids.#"] = strconv.Itoa(len(obj.Domains))
+	for idx, val := range obj.Domains {
+		key := fmt.Sprintf("domain_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["subnet_ids.#"] = strconv.Itoa(len(obj.Subnets))
+	for idx, val := range obj.Subnets {
+		key := fmt.Sprintf("subnet_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["parameters.%"] = strconv.Itoa(len(obj.OrganizationParameters))
+	for _, val := range obj.OrganizationParameters {
+		key := fmt.Sprintf("parameters.%s", val.Name)
+		attr[key] = val.Value
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanOrganization resource, create a
+// mock ResourceData reference.
+func MockForemanOrganizationResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanOrganization()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an organization
+// ResourceData reference
+func MockForemanOrganizationResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanOrganization
+	ParseJSONFile(t, path, &obj)
+	s := ForemanOrganizationToInstanceState(obj)
+	return MockForemanOrganizationResourceData(s)
+}
+
+// Creates a random ForemanOrganization struct
+func RandForemanOrganization() api.ForemanOrganization {
+	obj := api.ForemanOrganization{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Title = tfrand.String(20, tfrand.Lower+"/")
+	obj.Description = tfrand.String(30, tfrand.Lower)
+	obj.ParentId = rand.Intn(100)
+	obj.Domains = tfrand.IntArrayUnique(5)
+	obj.Subnets = tfrand.IntArrayUnique(5)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanOrganization resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanOrganizationResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanOrganization()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"domain_ids", "subnet_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+	params1 := r1.Get("parameters").(map[string]interface{})
+	params2 := r2.Get("parameters").(map[string]interface{})
+	if !reflect.DeepEqual(params1, params2) {
+		t.Fatalf(
+			"ResourceData references differ in parameters. "+
+				"[%v], [%v]",
+			params1,
+			params2,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestOrganizationUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanOrganization
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanOrganization
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanOrganization
+func TestBuildForemanOrganization(t *testing.T) {
+
+	expectedObj := RandForemanOrganization()
+	expectedState := ForemanOrganizationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanOrganizationResourceData(expectedState)
+
+	actualObj := *buildForemanOrganization(expectedResourceData)
+
+	actualState := ForemanOrganizationToInstanceState(actualObj)
+	actualResourceData := MockForemanOrganizationResourceData(actualState)
+
+	ForemanOrganizationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanOrganization
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanOrganization_Value(t *testing.T) {
+
+	expectedObj := RandForemanOrganization()
+	expectedState := ForemanOrganizationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanOrganizationResourceData(expectedState)
+
+	actualObj := api.ForemanOrganization{}
+	actualState := ForemanOrganizationToInstanceState(actualObj)
+	actualResourceData := MockForemanOrganizationResourceData(actualState)
+
+	setResourceDataFromForemanOrganization(actualResourceData, &expectedObj)
+
+	ForemanOrganizationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanOrganizationUpdate
+// -----------------------------------------------------------------------------
+
+// Ensures an update keeps the IDs of existing parameters, destroys removed
+// parameters and only sends the lists of IDs which are configured
+func TestResourceForemanOrganizationUpdate_Parameters(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	var reqData map[string]map[string]interface{}
+	mux.HandleFunc(OrganizationsURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&reqData)
+		}
+		fmt.Fprint(w, `{"id":1,"name":"org01","parameters":[`+
+			`{"id":5,"name":"ntp_server","value":"ntp.dev.dc1.company.com"},`+
+			`{"id":6,"name":"proxy","value":"proxy.dev.dc1.company.com"}]}`)
+	})
+
+	obj := api.ForemanOrganization{}
+	obj.Id = 1
+	obj.Name = "org01"
+	obj.OrganizationParameters = []api.ForemanKVParameter{
+		{Name: "ntp_server", Value: "ntp.dev.dc1.company.com"},
+		{Name: "proxy", Value: "proxy.dev.dc1.company.com"},
+	}
+	s := ForemanOrganizationToInstanceState(obj)
+
+	r := resourceForemanOrganization()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "org01",
+		"domain_ids": []interface{}{1, 2},
+		"parameters": map[string]interface{}{
+			"ntp_server": "ntp.prod.dc1.company.com",
+		},
+	})
+	diff, _ := r.Diff(s, config, nil)
+	rd, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+	if updateErr := resourceForemanOrganizationUpdate(rd, client); updateErr != nil {
+		t.Fatalf("resourceForemanOrganizationUpdate() returned an error: [%v]", updateErr)
+	}
+
+	sent := reqData["organization"]
+	expectedParams := []interface{}{
+		map[string]interface{}{
+			"id":           float64(5),
+			"name":         "ntp_server",
+			"value":        "ntp.prod.dc1.company.com",
+			"hidden_value": false,
+		},
+		map[string]interface{}{
+			"id":           float64(6),
+			"name":         "proxy",
+			"value":        "proxy.dev.dc1.company.com",
+			"hidden_value": false,
+			"_destroy":     true,
+		},
+	}
+	if !reflect.DeepEqual(sent["organization_parameters_attributes"], expectedParams) {
+		t.Errorf(
+			"Update sent the wrong parameters. Expected [%+v], got [%+v]",
+			expectedParams,
+			sent["organization_parameters_attributes"],
+		)
+	}
+	if _, ok := sent["domain_ids"]; !ok {
+		t.Errorf("Update did not send the configured domain_ids")
+	}
+	for _, key := range []string{"realm_ids", "subnet_ids", "user_ids"} {
+		if _, ok := sent[key]; ok {
+			t.Errorf("Update sent the unconfigured list [%s]", key)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanOrganizationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+	organizationsURIById := OrganizationsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURI:    OrganizationsURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURI:    organizationsURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURI:    organizationsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationDelete",
+				crudFunc:     resourceForemanOrganizationDelete,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURI:    organizationsURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanOrganizationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanOrganizationRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	rd := MockForemanOrganizationResourceData(s)
+	obj = *buildForemanOrganization(rd)
+	reqData, _ := api.WrapJson("organization", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanOrganizationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanOrganizationEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanOrganizationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+	}
+
+}
@@ -3,6 +3,7 @@ package foreman
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
@@ -24,6 +25,8 @@ func resourceForemanParameter() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customizeDiffForemanParameter,
+
 		Schema: map[string]*schema.Schema{
 
 			autodoc.MetaAttribute: &schema.Schema{
@@ -78,8 +81,29 @@ func resourceForemanParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressEquivalentForemanParameterValue,
+				Description: "Value of the parameter.  It must be valid for the " +
+					"parameter_type, ie: \"true\" for a \"boolean\".  The value is " +
+					"redacted from plan output since it may be hidden.",
+			},
+			"parameter_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "string",
+				ValidateFunc: validation.StringInSlice(foremanParameterTypes, false),
+				Description: fmt.Sprintf(
+					"Type Foreman parses the value as. Valid values: %s.",
+					strings.Join(foremanParameterTypes, ", "),
+				),
+			},
+			"hidden_value": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether Foreman hides the value of the parameter.",
 			},
 		},
 	}
@@ -125,6 +149,10 @@ func buildForemanParameter(d *schema.ResourceData) *api.ForemanParameter {
 	if attr, ok = d.GetOk("value"); ok {
 		parameter.Parameter.Value = attr.(string)
 	}
+	if attr, ok = d.GetOk("parameter_type"); ok {
+		parameter.Parameter.ParameterType = attr.(string)
+	}
+	parameter.Parameter.HiddenValue = d.Get("hidden_value").(bool)
 	return &parameter
 }
 
@@ -140,7 +168,15 @@ func setResourceDataFromForemanParameter(d *schema.ResourceData, fd *api.Foreman
 	d.Set("operatingsystem_id", fd.OperatingSystemID)
 	d.Set("subnet_id", fd.SubnetID)
 	d.Set("name", fd.Parameter.Name)
-	d.Set("value", fd.Parameter.Value)
+	// NOTE(ALL): See the note in setForemanKVParameters().  The known value of
+	//   a hidden parameter is kept.
+	if !fd.Parameter.HiddenValue || d.Get("value").(string) == "" {
+		d.Set("value", fd.Parameter.Value)
+	}
+	if fd.Parameter.ParameterType != "" {
+		d.Set("parameter_type", fd.Parameter.ParameterType)
+	}
+	d.Set("hidden_value", fd.Parameter.HiddenValue)
 }
 
 // -----------------------------------------------------------------------------
@@ -177,7 +213,7 @@ func resourceForemanParameterRead(d *schema.ResourceData, meta interface{}) erro
 
 	readParameter, readErr := client.ReadParameter(parameter, parameter.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanParameter: [%+v]", readParameter)
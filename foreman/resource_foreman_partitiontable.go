@@ -277,7 +277,7 @@ func resourceForemanPartitionTableRead(d *schema.ResourceData, meta interface{})
 
 	readTable, readErr := client.ReadPartitionTable(t.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanPartitionTable: [%+v]", readTable)
@@ -354,7 +354,7 @@ func ResourceForemanPartitionTableRequestDataTestCases(t *testing.T) []TestCaseR
 
 	rd := MockForemanPartitionTableResourceData(s)
 	obj = *buildForemanPartitionTable(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("ptable", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
@@ -352,7 +352,7 @@ func resourceForemanProvisioningTemplateRead(d *schema.ResourceData, meta interf
 
 	readTemplate, readErr := client.ReadProvisioningTemplate(t.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanProvisioningTemplate: [%+v]", readTemplate)
@@ -110,7 +110,7 @@ func resourceForemanPuppetClassRead(d *schema.ResourceData, meta interface{}) er
 
 	readPuppetClass, readErr := client.ReadPuppetClass(puppetclass.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanPuppetClass: [%+v]", readPuppetClass)
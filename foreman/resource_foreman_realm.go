@@ -0,0 +1,221 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanRealm() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanRealmCreate,
+		Read:   resourceForemanRealmRead,
+		Update: resourceForemanRealmUpdate,
+		Delete: resourceForemanRealmDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A realm. Realms are identity management domains, ie: "+
+						"FreeIPA or Active Directory, hosts are joined to during "+
+						"provisioning.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the realm. "+
+						"%s \"EXAMPLE.COM\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"realm_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"FreeIPA",
+					"Active Directory",
+				}, false),
+				Description: "Type of the realm. Valid values are `FreeIPA` and " +
+					"`Active Directory`.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"realm_proxy_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the smart proxy managing the realm. The smart " +
+					"proxy must have the realm feature.",
+			},
+
+			"location_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the realm belongs to.",
+			},
+
+			"organization_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the realm belongs to.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRealm constructs a ForemanRealm reference from a resource data
+// reference.  The struct's  members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanRealm(d *schema.ResourceData) *api.ForemanRealm {
+	log.Tracef("resource_foreman_realm.go#buildForemanRealm")
+
+	realm := api.ForemanRealm{}
+
+	obj := buildForemanObject(d)
+	realm.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("realm_type"); ok {
+		realm.RealmType = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("realm_proxy_id"); ok {
+		realm.RealmProxyId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("location_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		realm.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("organization_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		realm.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	return &realm
+}
+
+// setResourceDataFromForemanRealm sets a ResourceData's attributes from the
+// attributes of the supplied ForemanRealm reference
+func setResourceDataFromForemanRealm(d *schema.ResourceData, fr *api.ForemanRealm) {
+	log.Tracef("resource_foreman_realm.go#setResourceDataFromForemanRealm")
+
+	d.SetId(strconv.Itoa(fr.Id))
+	d.Set("name", fr.Name)
+	d.Set("realm_type", fr.RealmType)
+	d.Set("realm_proxy_id", fr.RealmProxyId)
+	d.Set("location_ids", fr.LocationIds)
+	d.Set("organization_ids", fr.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanRealmCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_realm.go#Create")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	createdRealm, createErr := client.CreateRealm(r)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanRealm: [%+v]", createdRealm)
+
+	setResourceDataFromForemanRealm(d, createdRealm)
+
+	return nil
+}
+
+func resourceForemanRealmRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_realm.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	readRealm, readErr := client.ReadRealm(r.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanRealm: [%+v]", readRealm)
+
+	setResourceDataFromForemanRealm(d, readRealm)
+
+	return nil
+}
+
+func resourceForemanRealmUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_realm.go#Update")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	updatedRealm, updateErr := client.UpdateRealm(r, r.Id)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanRealm: [%+v]", updatedRealm)
+
+	setResourceDataFromForemanRealm(d, updatedRealm)
+
+	return nil
+}
+
+func resourceForemanRealmDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_realm.go#Delete")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteRealm(r.Id)
+}
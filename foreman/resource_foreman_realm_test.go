@@ -0,0 +1,426 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const RealmsURI = api.FOREMAN_API_URL_PREFIX + "/realms"
+const RealmsTestDataPath = "testdata/1.11/realms"
+
+// Given a ForemanRealm, create a mock instance state reference
+func ForemanRealmToInstanceState(obj api.ForemanRealm) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanRealm
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["realm_type"] = obj.RealmType
+	attr["realm_proxy_id"] = strconv.Itoa(obj.RealmProxyId)
+	attr["location_ids.#"] = strconv.Itoa(len(obj.LocationIds))
+	for idx, val := range obj.LocationIds {
+		key := fmt.Sprintf("location_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["organization_ids.#"] = strconv.Itoa(len(obj.OrganizationIds))
+	for idx, val := range obj.OrganizationIds {
+		key := fmt.Sprintf("organization_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanRealm resource, create a
+// mock ResourceData reference.
+func MockForemanRealmResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanRealm()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a realm
+// ResourceData reference
+func MockForemanRealmResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanRealm
+	ParseJSONFile(t, path, &obj)
+	s := ForemanRealmToInstanceState(obj)
+	return MockForemanRealmResourceData(s)
+}
+
+// Creates a random ForemanRealm struct
+func RandForemanRealm() api.ForemanRealm {
+	obj := api.ForemanRealm{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.RealmType = "FreeIPA"
+	obj.RealmProxyId = rand.Intn(100) + 1
+	obj.LocationIds = tfrand.IntArrayUnique(5)
+	obj.OrganizationIds = tfrand.IntArrayUnique(5)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanRealm resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanRealmResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanRealm()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"location_ids", "organization_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestRealmUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanRealm
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanRealm UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanRealm UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanRealm
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanRealm
+func TestBuildForemanRealm(t *testing.T) {
+
+	expectedObj := RandForemanRealm()
+	expectedState := ForemanRealmToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRealmResourceData(expectedState)
+
+	actualObj := *buildForemanRealm(expectedResourceData)
+
+	actualState := ForemanRealmToInstanceState(actualObj)
+	actualResourceData := MockForemanRealmResourceData(actualState)
+
+	ForemanRealmResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanRealm
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanRealm_Value(t *testing.T) {
+
+	expectedObj := RandForemanRealm()
+	expectedState := ForemanRealmToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRealmResourceData(expectedState)
+
+	actualObj := api.ForemanRealm{}
+	actualState := ForemanRealmToInstanceState(actualObj)
+	actualResourceData := MockForemanRealmResourceData(actualState)
+
+	setResourceDataFromForemanRealm(actualResourceData, &expectedObj)
+
+	ForemanRealmResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanRealmCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanRealm{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRealmToInstanceState(obj)
+	realmsURIById := RealmsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmCreate",
+				crudFunc:     resourceForemanRealmCreate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedURI:    RealmsURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmRead",
+				crudFunc:     resourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedURI:    realmsURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmUpdate",
+				crudFunc:     resourceForemanRealmUpdate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedURI:    realmsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmDelete",
+				crudFunc:     resourceForemanRealmDelete,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedURI:    realmsURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanRealmRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanRealm{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRealmRead",
+			crudFunc:     resourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmDelete",
+			crudFunc:     resourceForemanRealmDelete,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanRealmRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanRealm{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRealmToInstanceState(obj)
+
+	rd := MockForemanRealmResourceData(s)
+	obj = *buildForemanRealm(rd)
+	reqData, _ := api.WrapJson("realm", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmCreate",
+				crudFunc:     resourceForemanRealmCreate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmUpdate",
+				crudFunc:     resourceForemanRealmUpdate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanRealmStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanRealm{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRealmCreate",
+			crudFunc:     resourceForemanRealmCreate,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmRead",
+			crudFunc:     resourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmUpdate",
+			crudFunc:     resourceForemanRealmUpdate,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmDelete",
+			crudFunc:     resourceForemanRealmDelete,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanRealmEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanRealm{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRealmCreate",
+			crudFunc:     resourceForemanRealmCreate,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmRead",
+			crudFunc:     resourceForemanRealmRead,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRealmUpdate",
+			crudFunc:     resourceForemanRealmUpdate,
+			resourceData: MockForemanRealmResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanRealmMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRealm()
+	s := ForemanRealmToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmCreate",
+				crudFunc:     resourceForemanRealmCreate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: RealmsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRealmResourceDataFromFile(
+				t,
+				RealmsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanRealmResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmRead",
+				crudFunc:     resourceForemanRealmRead,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: RealmsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRealmResourceDataFromFile(
+				t,
+				RealmsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanRealmResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRealmUpdate",
+				crudFunc:     resourceForemanRealmUpdate,
+				resourceData: MockForemanRealmResourceData(s),
+			},
+			responseFile: RealmsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRealmResourceDataFromFile(
+				t,
+				RealmsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanRealmResourceDataCompare,
+		},
+	}
+
+}
@@ -0,0 +1,203 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceForemanRole() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanRoleCreate,
+		Read:   resourceForemanRoleRead,
+		Update: resourceForemanRoleUpdate,
+		Delete: resourceForemanRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A role. Roles group filters, each granting a set of "+
+						"permissions, and are assigned to users and user groups.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the role. "+
+						"%s \"Host operator\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the role.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"location_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the role is limited to. Filters " +
+					"which do not override the role's taxonomies inherit them.",
+			},
+
+			"organization_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the role is limited to. " +
+					"Filters which do not override the role's taxonomies inherit them.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRole constructs a ForemanRole reference from a resource data
+// reference.  The struct's  members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanRole(d *schema.ResourceData) *api.ForemanRole {
+	log.Tracef("resource_foreman_role.go#buildForemanRole")
+
+	role := api.ForemanRole{}
+
+	obj := buildForemanObject(d)
+	role.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("description"); ok {
+		role.Description = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("location_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		role.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("organization_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		role.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	return &role
+}
+
+// setResourceDataFromForemanRole sets a ResourceData's attributes from the
+// attributes of the supplied ForemanRole reference
+func setResourceDataFromForemanRole(d *schema.ResourceData, fr *api.ForemanRole) {
+	log.Tracef("resource_foreman_role.go#setResourceDataFromForemanRole")
+
+	d.SetId(strconv.Itoa(fr.Id))
+	d.Set("name", fr.Name)
+	d.Set("description", fr.Description)
+	d.Set("location_ids", fr.LocationIds)
+	d.Set("organization_ids", fr.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_role.go#Create")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	createdRole, createErr := client.CreateRole(r)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanRole: [%+v]", createdRole)
+
+	setResourceDataFromForemanRole(d, createdRole)
+
+	return nil
+}
+
+func resourceForemanRoleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	readRole, readErr := client.ReadRole(r.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanRole: [%+v]", readRole)
+
+	setResourceDataFromForemanRole(d, readRole)
+
+	return nil
+}
+
+func resourceForemanRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_role.go#Update")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	updatedRole, updateErr := client.UpdateRole(r)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanRole: [%+v]", updatedRole)
+
+	setResourceDataFromForemanRole(d, updatedRole)
+
+	return nil
+}
+
+func resourceForemanRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_role.go#Delete")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteRole(r.Id)
+}
@@ -0,0 +1,424 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const RolesURI = api.FOREMAN_API_URL_PREFIX + "/roles"
+const RolesTestDataPath = "testdata/1.11/roles"
+
+// Given a ForemanRole, create a mock instance state reference
+func ForemanRoleToInstanceState(obj api.ForemanRole) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanRole
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["description"] = obj.Description
+	attr["location_ids.#"] = strconv.Itoa(len(obj.LocationIds))
+	for idx, val := range obj.LocationIds {
+		key := fmt.Sprintf("location_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["organization_ids.#"] = strconv.Itoa(len(obj.OrganizationIds))
+	for idx, val := range obj.OrganizationIds {
+		key := fmt.Sprintf("organization_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanRole resource, create a
+// mock ResourceData reference.
+func MockForemanRoleResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanRole()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a role
+// ResourceData reference
+func MockForemanRoleResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanRole
+	ParseJSONFile(t, path, &obj)
+	s := ForemanRoleToInstanceState(obj)
+	return MockForemanRoleResourceData(s)
+}
+
+// Creates a random ForemanRole struct
+func RandForemanRole() api.ForemanRole {
+	obj := api.ForemanRole{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Description = tfrand.String(30, tfrand.Lower)
+	obj.LocationIds = tfrand.IntArrayUnique(5)
+	obj.OrganizationIds = tfrand.IntArrayUnique(5)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanRole resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanRoleResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanRole()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"location_ids", "organization_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestRoleUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanRole
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanRole
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanRole
+func TestBuildForemanRole(t *testing.T) {
+
+	expectedObj := RandForemanRole()
+	expectedState := ForemanRoleToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRoleResourceData(expectedState)
+
+	actualObj := *buildForemanRole(expectedResourceData)
+
+	actualState := ForemanRoleToInstanceState(actualObj)
+	actualResourceData := MockForemanRoleResourceData(actualState)
+
+	ForemanRoleResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanRole
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanRole_Value(t *testing.T) {
+
+	expectedObj := RandForemanRole()
+	expectedState := ForemanRoleToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRoleResourceData(expectedState)
+
+	actualObj := api.ForemanRole{}
+	actualState := ForemanRoleToInstanceState(actualObj)
+	actualResourceData := MockForemanRoleResourceData(actualState)
+
+	setResourceDataFromForemanRole(actualResourceData, &expectedObj)
+
+	ForemanRoleResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanRoleCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+	rolesURIById := RolesURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURI:    RolesURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURI:    rolesURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleUpdate",
+				crudFunc:     resourceForemanRoleUpdate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURI:    rolesURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleDelete",
+				crudFunc:     resourceForemanRoleDelete,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURI:    rolesURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanRoleRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanRoleRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+
+	rd := MockForemanRoleResourceData(s)
+	obj = *buildForemanRole(rd)
+	reqData, _ := api.WrapJson("role", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleUpdate",
+				crudFunc:     resourceForemanRoleUpdate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanRoleStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanRoleEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanRoleMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleUpdate",
+				crudFunc:     resourceForemanRoleUpdate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+	}
+
+}
@@ -144,7 +144,7 @@ func resourceForemanSmartClassParameterRead(d *schema.ResourceData, meta interfa
 
 	readSmartClassParameter, readErr := client.ReadSmartClassParameter(smartclassparameter)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanSmartClassParameter: [%+v]", readSmartClassParameter)
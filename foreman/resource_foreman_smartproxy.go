@@ -3,7 +3,9 @@ package foreman
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
@@ -79,6 +81,64 @@ func resourceForemanSmartProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"features": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: fmt.Sprintf(
+								"Name of the feature. "+
+									"%s \"DHCP\"",
+								autodoc.MetaExample,
+							),
+						},
+						"capabilities": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "Capabilities of the feature.",
+						},
+					},
+				},
+				Description: "Features advertised by the smart proxy. The features " +
+					"are discovered when the smart proxy is registered and refreshed " +
+					"when the URL changes or the `refresh_trigger` changes.",
+			},
+
+			"refresh_trigger": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: fmt.Sprintf(
+					"Arbitrary map of values. Any change to the map refreshes the "+
+						"features of the smart proxy. "+
+						"%s { proxy_version = \"1.24\" }",
+					autodoc.MetaExample,
+				),
+			},
+
+			"required_features": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: fmt.Sprintf(
+					"Names of the features the smart proxy must advertise. The "+
+						"apply fails if one of the features is not advertised after "+
+						"the smart proxy was registered, updated or refreshed. "+
+						"%s [\"DHCP\", \"TFTP\"]",
+					autodoc.MetaExample,
+				),
+			},
 		},
 	}
 }
@@ -112,6 +172,43 @@ func setResourceDataFromForemanSmartProxy(d *schema.ResourceData, fp *api.Forema
 	d.SetId(strconv.Itoa(fp.Id))
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+
+	features := make([]map[string]interface{}, len(fp.Features))
+	for idx, feature := range fp.Features {
+		features[idx] = map[string]interface{}{
+			"name":         feature.Name,
+			"capabilities": feature.Capabilities,
+		}
+	}
+	d.Set("features", features)
+}
+
+// checkForemanSmartProxyRequiredFeatures returns an error if the supplied
+// ForemanSmartProxy does not advertise all of the features listed in the
+// resource data's required_features.
+func checkForemanSmartProxyRequiredFeatures(d *schema.ResourceData, fp *api.ForemanSmartProxy) error {
+	log.Tracef("resource_foreman_smartproxy.go#checkForemanSmartProxyRequiredFeatures")
+
+	attr, ok := d.GetOk("required_features")
+	if !ok {
+		return nil
+	}
+
+	var missing []string
+	for _, name := range attr.(*schema.Set).List() {
+		if !fp.HasFeature(name.(string)) {
+			missing = append(missing, name.(string))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf(
+			"Smart proxy [%s] does not advertise the required features [%s]",
+			fp.Name,
+			strings.Join(missing, ", "),
+		)
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -135,7 +232,7 @@ func resourceForemanSmartProxyCreate(d *schema.ResourceData, meta interface{}) e
 
 	setResourceDataFromForemanSmartProxy(d, createdSmartProxy)
 
-	return nil
+	return checkForemanSmartProxyRequiredFeatures(d, createdSmartProxy)
 }
 
 func resourceForemanSmartProxyRead(d *schema.ResourceData, meta interface{}) error {
@@ -148,7 +245,7 @@ func resourceForemanSmartProxyRead(d *schema.ResourceData, meta interface{}) err
 
 	readSmartProxy, readErr := client.ReadSmartProxy(s.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanSmartProxy: [%+v]", readSmartProxy)
@@ -166,6 +263,12 @@ func resourceForemanSmartProxyUpdate(d *schema.ResourceData, meta interface{}) e
 
 	log.Debugf("ForemanSmartProxy: [%+v]", s)
 
+	// NOTE(ALL): Enable partial state mode.  The URL, refresh trigger and
+	//   required features are only saved once the features were refreshed and
+	//   the required features are advertised, so a failed refresh or check is
+	//   retried by the next apply.
+	d.Partial(true)
+
 	updatedSmartProxy, updateErr := client.UpdateSmartProxy(s)
 	if updateErr != nil {
 		return updateErr
@@ -173,7 +276,39 @@ func resourceForemanSmartProxyUpdate(d *schema.ResourceData, meta interface{}) e
 
 	log.Debugf("ForemanSmartProxy: [%+v]", updatedSmartProxy)
 
+	d.Set("name", updatedSmartProxy.Name)
+	d.SetPartial("name")
+
+	// NOTE(ALL): A proxy at a new URL may advertise different features.
+	//   Foreman only discovers the features on registration, refresh them.
+	if d.HasChange("url") || d.HasChange("refresh_trigger") {
+		refreshErr := client.RefreshSmartProxyFeatures(updatedSmartProxy.Id)
+		if refreshErr != nil {
+			return refreshErr
+		}
+
+		var readErr error
+		updatedSmartProxy, readErr = client.ReadSmartProxy(updatedSmartProxy.Id)
+		if readErr != nil {
+			return readErr
+		}
+
+		log.Debugf("Refreshed ForemanSmartProxy: [%+v]", updatedSmartProxy)
+	}
+
 	setResourceDataFromForemanSmartProxy(d, updatedSmartProxy)
+	d.SetPartial("features")
+
+	checkErr := checkForemanSmartProxyRequiredFeatures(d, updatedSmartProxy)
+	if checkErr != nil {
+		return checkErr
+	}
+
+	d.SetPartial("url")
+	d.SetPartial("refresh_trigger")
+	d.SetPartial("required_features")
+
+	d.Partial(false)
 
 	return nil
 }
@@ -2,6 +2,7 @@ package foreman
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -30,6 +31,15 @@ func ForemanSmartProxyToInstanceState(obj api.ForemanSmartProxy) *terraform.Inst
 	attr := map[string]string{}
 	attr["name"] = obj.Name
 	attr["url"] = obj.URL
+	attr["features.#"] = strconv.Itoa(len(obj.Features))
+	for idx, feature := range obj.Features {
+		key := fmt.Sprintf("features.%d", idx)
+		attr[key+".name"] = feature.Name
+		attr[key+".capabilities.#"] = strconv.Itoa(len(feature.Capabilities))
+		for capIdx, capability := range feature.Capabilities {
+			attr[fmt.Sprintf("%s.capabilities.%d", key, capIdx)] = capability
+		}
+	}
 	state.Attributes = attr
 	return &state
 }
@@ -59,6 +69,15 @@ func RandForemanSmartProxy() api.ForemanSmartProxy {
 
 	obj.URL = tfrand.String(30, tfrand.Lower+"/:.")
 
+	obj.Features = make([]api.ForemanSmartProxyFeature, rand.Intn(3)+1)
+	for idx := range obj.Features {
+		obj.Features[idx].Name = tfrand.String(10, tfrand.Lower)
+		obj.Features[idx].Capabilities = []string{
+			tfrand.String(10, tfrand.Lower),
+			tfrand.String(10, tfrand.Lower),
+		}
+	}
+
 	return obj
 }
 
@@ -86,6 +105,16 @@ func ForemanSmartProxyResourceDataCompare(t *testing.T, r1 *schema.ResourceData,
 	// compare the rest of the attributes
 	CompareResourceDataAttributes(t, m, r1, r2)
 
+	features1 := r1.Get("features")
+	features2 := r2.Get("features")
+	if !reflect.DeepEqual(features1, features2) {
+		t.Fatalf(
+			"ResourceData references differ in features. [%v], [%v]",
+			features1,
+			features2,
+		)
+	}
+
 }
 
 // -----------------------------------------------------------------------------
@@ -129,6 +158,9 @@ func TestSmartProxyUnmarshalJSON_ForemanObject(t *testing.T) {
 func TestBuildForemanSmartProxy(t *testing.T) {
 
 	expectedObj := RandForemanSmartProxy()
+	// features are discovered by Foreman, they are never built from the
+	// resource data
+	expectedObj.Features = nil
 	expectedState := ForemanSmartProxyToInstanceState(expectedObj)
 	expectedResourceData := MockForemanSmartProxyResourceData(expectedState)
 
@@ -162,6 +194,128 @@ func TestSetResourceDataFromForemanSmartProxy_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// checkForemanSmartProxyRequiredFeatures
+// -----------------------------------------------------------------------------
+
+// Ensures an error is only returned if one of the required features is not
+// advertised by the smart proxy
+func TestCheckForemanSmartProxyRequiredFeatures(t *testing.T) {
+
+	obj := RandForemanSmartProxy()
+	obj.Features = []api.ForemanSmartProxyFeature{
+		api.ForemanSmartProxyFeature{ForemanObject: api.ForemanObject{Name: "DHCP"}},
+		api.ForemanSmartProxyFeature{ForemanObject: api.ForemanObject{Name: "TFTP"}},
+	}
+
+	testCases := []struct {
+		requiredFeatures []interface{}
+		returnError      bool
+	}{
+		{nil, false},
+		{[]interface{}{"DHCP"}, false},
+		{[]interface{}{"DHCP", "TFTP"}, false},
+		{[]interface{}{"DHCP", "DNS"}, true},
+	}
+
+	for _, testCase := range testCases {
+		rd := MockForemanSmartProxyResourceData(ForemanSmartProxyToInstanceState(obj))
+		rd.Set("required_features", testCase.requiredFeatures)
+
+		checkErr := checkForemanSmartProxyRequiredFeatures(rd, &obj)
+		if (checkErr != nil) != testCase.returnError {
+			t.Errorf(
+				"checkForemanSmartProxyRequiredFeatures() returned an unexpected "+
+					"result for the required features [%v]. Expected error [%t], "+
+					"got [%v]",
+				testCase.requiredFeatures,
+				testCase.returnError,
+				checkErr,
+			)
+		}
+	}
+
+}
+
+// Ensures a URL change refreshes the smart proxy's features and the update
+// fails if a required feature is not advertised after the refresh
+func TestResourceForemanSmartProxyUpdate_RefreshFeatures(t *testing.T) {
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	refreshes := 0
+	mux.HandleFunc(SmartProxiesURI+"/1", func(w http.ResponseWriter, r *http.Request) {
+		if refreshes == 0 {
+			fmt.Fprint(w, `{"id":1,"name":"proxy01","url":"https://proxy02:8443","features":[{"id":3,"name":"DHCP"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"name":"proxy01","url":"https://proxy02:8443","features":[{"id":3,"name":"DHCP"},{"id":4,"name":"TFTP","capabilities":["http_boot"]}]}`)
+	})
+	mux.HandleFunc(SmartProxiesURI+"/1/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Unexpected request [%s %s]", r.Method, r.URL)
+		}
+		refreshes++
+		fmt.Fprint(w, `{"id":1,"name":"proxy01"}`)
+	})
+
+	obj := api.ForemanSmartProxy{}
+	obj.Id = 1
+	obj.Name = "proxy01"
+	obj.URL = "https://proxy01:8443"
+	s := ForemanSmartProxyToInstanceState(obj)
+
+	// NOTE(ALL): HasChange() only detects changes from a diff, build the
+	//   resource data from the state and a diff against the new config
+	r := resourceForemanSmartProxy()
+	newResourceData := func(requiredFeatures []interface{}) *schema.ResourceData {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "proxy01",
+			"url":               "https://proxy02:8443",
+			"required_features": requiredFeatures,
+		})
+		diff, _ := r.Diff(s, config, nil)
+		rd, _ := schema.InternalMap(r.Schema).Data(s, diff)
+		return rd
+	}
+
+	rd := newResourceData([]interface{}{"DHCP", "TFTP"})
+	if updateErr := resourceForemanSmartProxyUpdate(rd, client); updateErr != nil {
+		t.Fatalf("resourceForemanSmartProxyUpdate() returned an error: [%v]", updateErr)
+	}
+	if refreshes != 1 || rd.Get("features.#").(int) != 2 || rd.Get("features.1.capabilities.0").(string) != "http_boot" {
+		t.Errorf(
+			"URL change did not refresh the features. Expected [1] refresh and "+
+				"[2] features, got [%d] refreshes and features [%v]",
+			refreshes,
+			rd.Get("features"),
+		)
+	}
+
+	rd = newResourceData([]interface{}{"DNS"})
+	if updateErr := resourceForemanSmartProxyUpdate(rd, client); updateErr == nil {
+		t.Errorf(
+			"resourceForemanSmartProxyUpdate() did not return an error for the " +
+				"missing required feature [DNS]",
+		)
+	}
+
+	// NOTE(ALL): the new URL must not be saved to the state, so the next apply
+	//   refreshes the features and checks the required features again
+	attrs := rd.State().Attributes
+	if attrs["url"] != obj.URL || attrs["required_features.#"] == "1" {
+		t.Errorf(
+			"Failed update saved the new URL or required features to the state. "+
+				"Expected url [%s] and no required features, got [%s] and [%s]",
+			obj.URL,
+			attrs["url"],
+			attrs["required_features.#"],
+		)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -245,7 +399,7 @@ func ResourceForemanSmartProxyRequestDataTestCases(t *testing.T) []TestCaseReque
 
 	rd := MockForemanSmartProxyResourceData(s)
 	obj = *buildForemanSmartProxy(rd)
-	reqData, _ := json.Marshal(obj)
+	reqData, _ := api.WrapJson("smart_proxy", obj)
 
 	return []TestCaseRequestData{
 		TestCaseRequestData{
@@ -129,6 +129,83 @@ func resourceForemanSubnet() *schema.Resource {
 					"Values include: `\"Static\"`, `\"DHCP\"`.",
 			},
 
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the subnet.",
+			},
+
+			"vlanid": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 4095),
+				Description:  "VLAN ID for this subnet.",
+			},
+
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(68),
+				Description: "MTU for this subnet. Foreman defaults to 1500 " +
+					"if not supplied.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"dhcp_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the smart proxy providing DHCP for this subnet.",
+			},
+
+			"tftp_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the smart proxy providing TFTP for this subnet.",
+			},
+
+			"dns_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the smart proxy providing reverse DNS for this subnet.",
+			},
+
+			"template_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the smart proxy providing templates for this subnet.",
+			},
+
+			"bmc_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the smart proxy providing BMC for this subnet.",
+			},
+
+			"externalipam_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the smart proxy providing external IPAM for " +
+					"this subnet.",
+			},
+
+			"remote_execution_proxy_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the smart proxies used for remote execution " +
+					"on this subnet. Requires the remote execution plugin.",
+			},
+
 			"domain_ids": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -138,6 +215,14 @@ func resourceForemanSubnet() *schema.Resource {
 				},
 				Description: "IDs of the domain_ids.",
 			},
+
+			"organization_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the organization the subnet belongs to.",
+			},
 		},
 	}
 }
@@ -185,12 +270,45 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 	if attr, ok = d.GetOk("boot_mode"); ok {
 		s.BootMode = attr.(string)
 	}
+	if attr, ok = d.GetOk("description"); ok {
+		s.Description = attr.(string)
+	}
+	if attr, ok = d.GetOk("vlanid"); ok {
+		s.Vlanid = attr.(int)
+	}
+	if attr, ok = d.GetOk("mtu"); ok {
+		s.Mtu = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("dhcp_id"); ok {
+		s.DhcpId = attr.(int)
+	}
+	if attr, ok = d.GetOk("tftp_id"); ok {
+		s.TftpId = attr.(int)
+	}
+	if attr, ok = d.GetOk("dns_id"); ok {
+		s.DnsId = attr.(int)
+	}
+	if attr, ok = d.GetOk("template_id"); ok {
+		s.TemplateId = attr.(int)
+	}
+	if attr, ok = d.GetOk("bmc_id"); ok {
+		s.BmcId = attr.(int)
+	}
+	if attr, ok = d.GetOk("externalipam_id"); ok {
+		s.ExternalIpamId = attr.(int)
+	}
+	s.RemoteExecutionProxyIds = buildForemanIds(d, "remote_execution_proxy_ids")
 
 	if attr, ok = d.GetOk("domain_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		s.Domains = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
 
+	if attr, ok = d.GetOk("organization_id"); ok {
+		s.OrganizationId = attr.(int)
+	}
+
 	return &s
 }
 
@@ -210,7 +328,18 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 	d.Set("from", fs.From)
 	d.Set("to", fs.To)
 	d.Set("boot_mode", fs.BootMode)
+	d.Set("description", fs.Description)
+	d.Set("vlanid", fs.Vlanid)
+	d.Set("mtu", fs.Mtu)
+	d.Set("dhcp_id", fs.DhcpId)
+	d.Set("tftp_id", fs.TftpId)
+	d.Set("dns_id", fs.DnsId)
+	d.Set("template_id", fs.TemplateId)
+	d.Set("bmc_id", fs.BmcId)
+	d.Set("externalipam_id", fs.ExternalIpamId)
+	d.Set("remote_execution_proxy_ids", fs.RemoteExecutionProxyIds)
 	d.Set("domain_ids", fs.Domains)
+	d.Set("organization_id", fs.OrganizationId)
 }
 
 // -----------------------------------------------------------------------------
@@ -219,6 +348,21 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 
 func resourceForemanSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Create")
+
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
+	createdSubnet, createErr := client.CreateSubnet(s)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanSubnet: [%+v]", createdSubnet)
+
+	setResourceDataFromForemanSubnet(d, createdSubnet)
+
 	return nil
 }
 
@@ -232,7 +376,7 @@ func resourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error {
 
 	readSubnet, readErr := client.ReadSubnet(s.Id)
 	if readErr != nil {
-		return readErr
+		return handleNotFoundError(readErr, d)
 	}
 
 	log.Debugf("Read ForemanSubnet: [%+v]", readSubnet)
@@ -244,14 +388,33 @@ func resourceForemanSubnetRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceForemanSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Update")
+
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
+	updatedSubnet, updateErr := client.UpdateSubnet(s)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanSubnet: [%+v]", updatedSubnet)
+
+	setResourceDataFromForemanSubnet(d, updatedSubnet)
+
 	return nil
 }
 
 func resourceForemanSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Tracef("resource_foreman_subnet.go#Delete")
 
+	client := meta.(*api.Client)
+	s := buildForemanSubnet(d)
+
+	log.Debugf("ForemanSubnet: [%+v]", s)
+
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
-
-	return nil
+	return client.DeleteSubnet(s.Id)
 }
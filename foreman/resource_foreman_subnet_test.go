@@ -38,6 +38,15 @@ func ForemanSubnetToInstanceState(obj api.ForemanSubnet) *terraform.InstanceStat
 	attr["from"] = obj.From
 	attr["to"] = obj.To
 	attr["boot_mode"] = obj.BootMode
+	attr["description"] = obj.Description
+	attr["vlanid"] = strconv.Itoa(obj.Vlanid)
+	attr["mtu"] = strconv.Itoa(obj.Mtu)
+	attr["dhcp_id"] = strconv.Itoa(obj.DhcpId)
+	attr["tftp_id"] = strconv.Itoa(obj.TftpId)
+	attr["dns_id"] = strconv.Itoa(obj.DnsId)
+	attr["template_id"] = strconv.Itoa(obj.TemplateId)
+	attr["bmc_id"] = strconv.Itoa(obj.BmcId)
+	attr["externalipam_id"] = strconv.Itoa(obj.ExternalIpamId)
 	state.Attributes = attr
 	return &state
 }
@@ -74,6 +83,15 @@ func RandForemanSubnet() api.ForemanSubnet {
 	obj.From = tfrand.IPv4Str(tfrand.IPv4PrivateClassCStart, tfrand.IPv4PrivateClassCMask)
 	obj.To = tfrand.IPv4Str(tfrand.IPv4PrivateClassCStart, tfrand.IPv4PrivateClassCMask)
 	obj.BootMode = tfrand.String(5, tfrand.Lower)
+	obj.Description = tfrand.String(20, tfrand.Lower)
+	obj.Vlanid = rand.Intn(4095)
+	obj.Mtu = 1500
+	obj.DhcpId = rand.Intn(100)
+	obj.TftpId = rand.Intn(100)
+	obj.DnsId = rand.Intn(100)
+	obj.TemplateId = rand.Intn(100)
+	obj.BmcId = rand.Intn(100)
+	obj.ExternalIpamId = rand.Intn(100)
 
 	return obj
 }
@@ -136,6 +154,106 @@ func TestSubnetUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal reads the smart proxy associations from both the
+// "<proxy>_id" keys and the nested objects returned by older Foreman versions
+func TestSubnetUnmarshalJSON_ProxyIds(t *testing.T) {
+
+	var nested api.ForemanSubnet
+	ParseJSONFile(t, SubnetsTestDataPath+"/read_response.json", &nested)
+	if nested.DhcpId != 38 || nested.TftpId != 38 || nested.DnsId != 39 {
+		t.Errorf(
+			"ForemanSubnet UnmarshalJSON did not decode the nested proxies. "+
+				"Expected [38 38 39], got [%d %d %d]",
+			nested.DhcpId,
+			nested.TftpId,
+			nested.DnsId,
+		)
+	}
+
+	var flat api.ForemanSubnet
+	ParseJSONFile(t, SubnetsTestDataPath+"/create_response.json", &flat)
+	if flat.DhcpId != 38 || flat.DnsId != 39 || flat.TemplateId != 38 || flat.BmcId != 0 {
+		t.Errorf(
+			"ForemanSubnet UnmarshalJSON did not decode the proxy IDs. "+
+				"Expected [38 39 38 0], got [%d %d %d %d]",
+			flat.DhcpId,
+			flat.DnsId,
+			flat.TemplateId,
+			flat.BmcId,
+		)
+	}
+	if !reflect.DeepEqual(flat.RemoteExecutionProxyIds, []int{38}) {
+		t.Errorf(
+			"ForemanSubnet UnmarshalJSON did not decode the remote execution "+
+				"proxies. Expected [[38]], got [%v]",
+			flat.RemoteExecutionProxyIds,
+		)
+	}
+	if flat.Vlanid != 248 || flat.Mtu != 9000 {
+		t.Errorf(
+			"ForemanSubnet UnmarshalJSON did not decode the VLAN ID and MTU. "+
+				"Expected [248 9000], got [%d %d]",
+			flat.Vlanid,
+			flat.Mtu,
+		)
+	}
+
+}
+
+// Ensures remote_execution_proxy_ids is only sent when it is configured or
+// changed and an emptied list is sent to remove the proxies
+func TestBuildForemanSubnet_RemoteExecutionProxyIds(t *testing.T) {
+	r := resourceForemanSubnet()
+	newConfig := func(proxyIds []interface{}) map[string]interface{} {
+		cfg := map[string]interface{}{
+			"name":    "subnet01",
+			"network": "10.228.0.0",
+			"mask":    "255.255.255.0",
+		}
+		if proxyIds != nil {
+			cfg["remote_execution_proxy_ids"] = proxyIds
+		}
+		return cfg
+	}
+
+	testCases := []struct {
+		state    []interface{}
+		config   []interface{}
+		expected interface{}
+	}{
+		{nil, nil, nil},
+		{nil, []interface{}{38}, []interface{}{float64(38)}},
+		{[]interface{}{38}, []interface{}{38}, []interface{}{float64(38)}},
+		{[]interface{}{38}, nil, []interface{}{}},
+	}
+
+	for _, testCase := range testCases {
+		stateData := schema.TestResourceDataRaw(t, r.Schema, newConfig(testCase.state))
+		stateData.SetId("1")
+		state := stateData.State()
+
+		config := terraform.NewResourceConfigRaw(newConfig(testCase.config))
+		diff, _ := r.Diff(state, config, nil)
+		rd, _ := schema.InternalMap(r.Schema).Data(state, diff)
+
+		subnetBytes, _ := json.Marshal(buildForemanSubnet(rd))
+		var subnetMap map[string]interface{}
+		json.Unmarshal(subnetBytes, &subnetMap)
+
+		actual, sent := subnetMap["remote_execution_proxy_ids"]
+		if (testCase.expected == nil && sent) || !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf(
+				"buildForemanSubnet() with state [%v] and config [%v] sent the "+
+					"wrong remote_execution_proxy_ids. Expected [%v], got [%v]",
+				testCase.state,
+				testCase.config,
+				testCase.expected,
+				actual,
+			)
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
 // setResourceDataFromForemanSubnet
 // -----------------------------------------------------------------------------
@@ -167,18 +285,45 @@ func ResourceForemanSubnetCorrectURLAndMethodTestCases(t *testing.T) []TestCaseC
 	obj := api.ForemanSubnet{}
 	obj.Id = rand.Intn(100)
 	s := ForemanSubnetToInstanceState(obj)
-	architecturesURIById := SubnetsURI + "/" + strconv.Itoa(obj.Id)
+	subnetsURIById := SubnetsURI + "/" + strconv.Itoa(obj.Id)
 
 	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetCreate",
+				crudFunc:     resourceForemanSubnetCreate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    SubnetsURI,
+			expectedMethod: http.MethodPost,
+		},
 		TestCaseCorrectURLAndMethod{
 			TestCase: TestCase{
 				funcName:     "resourceForemanSubnetRead",
 				crudFunc:     resourceForemanSubnetRead,
 				resourceData: MockForemanSubnetResourceData(s),
 			},
-			expectedURI:    architecturesURIById,
+			expectedURI:    subnetsURIById,
 			expectedMethod: http.MethodGet,
 		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetUpdate",
+				crudFunc:     resourceForemanSubnetUpdate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    subnetsURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetDelete",
+				crudFunc:     resourceForemanSubnetDelete,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedURI:    subnetsURIById,
+			expectedMethod: http.MethodDelete,
+		},
 	}
 
 }
@@ -196,7 +341,44 @@ func ResourceForemanSubnetRequestDataEmptyTestCases(t *testing.T) []TestCase {
 			crudFunc:     resourceForemanSubnetRead,
 			resourceData: MockForemanSubnetResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanSubnetDelete",
+			crudFunc:     resourceForemanSubnetDelete,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanSubnetRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanSubnet{}
+	obj.Id = rand.Intn(100)
+	s := ForemanSubnetToInstanceState(obj)
+
+	rd := MockForemanSubnetResourceData(s)
+	obj = *buildForemanSubnet(rd)
+	reqData, _ := api.WrapJson("subnet", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetCreate",
+				crudFunc:     resourceForemanSubnetCreate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetUpdate",
+				crudFunc:     resourceForemanSubnetUpdate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			expectedData: reqData,
+		},
 	}
+
 }
 
 // SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
@@ -207,11 +389,26 @@ func ResourceForemanSubnetStatusCodeTestCases(t *testing.T) []TestCase {
 	s := ForemanSubnetToInstanceState(obj)
 
 	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanSubnetCreate",
+			crudFunc:     resourceForemanSubnetCreate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 		TestCase{
 			funcName:     "resourceForemanSubnetRead",
 			crudFunc:     resourceForemanSubnetRead,
 			resourceData: MockForemanSubnetResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanSubnetUpdate",
+			crudFunc:     resourceForemanSubnetUpdate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanSubnetDelete",
+			crudFunc:     resourceForemanSubnetDelete,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 	}
 }
 
@@ -222,11 +419,21 @@ func ResourceForemanSubnetEmptyResponseTestCases(t *testing.T) []TestCase {
 	s := ForemanSubnetToInstanceState(obj)
 
 	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanSubnetCreate",
+			crudFunc:     resourceForemanSubnetCreate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 		TestCase{
 			funcName:     "resourceForemanSubnetRead",
 			crudFunc:     resourceForemanSubnetRead,
 			resourceData: MockForemanSubnetResourceData(s),
 		},
+		TestCase{
+			funcName:     "resourceForemanSubnetUpdate",
+			crudFunc:     resourceForemanSubnetUpdate,
+			resourceData: MockForemanSubnetResourceData(s),
+		},
 	}
 }
 
@@ -237,6 +444,23 @@ func ResourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockResp
 	s := ForemanSubnetToInstanceState(obj)
 
 	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetCreate",
+				crudFunc:     resourceForemanSubnetCreate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			responseFile: SubnetsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanSubnetResourceDataFromFile(
+				t,
+				SubnetsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanSubnetResourceDataCompare,
+		},
 		// If the server responds with a proper read response, the operation
 		// should succeed and the ResourceData's attributes should be updated
 		// to server's response
@@ -254,6 +478,23 @@ func ResourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockResp
 			),
 			compareFunc: ForemanSubnetResourceDataCompare,
 		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSubnetUpdate",
+				crudFunc:     resourceForemanSubnetUpdate,
+				resourceData: MockForemanSubnetResourceData(s),
+			},
+			responseFile: SubnetsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanSubnetResourceDataFromFile(
+				t,
+				SubnetsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanSubnetResourceDataCompare,
+		},
 	}
 
 }
@@ -0,0 +1,322 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceForemanUser() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanUserCreate,
+		Read:   resourceForemanUserRead,
+		Update: resourceForemanUserUpdate,
+		Delete: resourceForemanUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A user account. Users authenticate against an authentication "+
+						"source and are granted permissions through their roles.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"login": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Login name of the user. "+
+						"%s \"jsmith\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"firstname": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "First name of the user.",
+			},
+
+			"lastname": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Last name of the user.",
+			},
+
+			"mail": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"E-mail address of the user. "+
+						"%s \"jsmith@company.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the user.",
+			},
+
+			"admin": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the user is an administrator. " +
+					"Defaults to `false`.",
+			},
+
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Password of the user. Only used for users of the " +
+					"internal authentication source. Foreman never returns the " +
+					"password, so changes made outside of terraform are not detected.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"auth_source_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the authentication source the user authenticates " +
+					"against. Foreman uses its internal authentication source if not set.",
+			},
+
+			"default_location_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the location the user logs into by default. The " +
+					"location must be one of `location_ids`.",
+			},
+
+			"default_organization_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the organization the user logs into by default. " +
+					"The organization must be one of `organization_ids`.",
+			},
+
+			"location_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the user is a member of.",
+			},
+
+			"organization_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the user is a member of.",
+			},
+
+			"role_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the roles assigned to the user.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanUser constructs a ForemanUser reference from a resource data
+// reference.  The struct's  members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanUser(d *schema.ResourceData) *api.ForemanUser {
+	log.Tracef("resource_foreman_user.go#buildForemanUser")
+
+	user := api.ForemanUser{}
+
+	obj := buildForemanObject(d)
+	user.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("login"); ok {
+		user.Login = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("firstname"); ok {
+		user.Firstname = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("lastname"); ok {
+		user.Lastname = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("mail"); ok {
+		user.Mail = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("description"); ok {
+		user.Description = attr.(string)
+	}
+
+	user.Admin = d.Get("admin").(bool)
+
+	if attr, ok = d.GetOk("password"); ok {
+		user.Password = attr.(string)
+	}
+
+	if attr, ok = d.GetOk("auth_source_id"); ok {
+		user.AuthSourceId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("default_location_id"); ok {
+		user.DefaultLocationId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("default_organization_id"); ok {
+		user.DefaultOrganizationId = attr.(int)
+	}
+
+	if attr, ok = d.GetOk("location_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		user.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("organization_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		user.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("role_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		user.RoleIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	return &user
+}
+
+// setResourceDataFromForemanUser sets a ResourceData's attributes from the
+// attributes of the supplied ForemanUser reference
+func setResourceDataFromForemanUser(d *schema.ResourceData, fu *api.ForemanUser) {
+	log.Tracef("resource_foreman_user.go#setResourceDataFromForemanUser")
+
+	// NOTE(ALL): the password is never returned by Foreman, keep the value
+	//   from the configuration
+
+	d.SetId(strconv.Itoa(fu.Id))
+	d.Set("login", fu.Login)
+	d.Set("firstname", fu.Firstname)
+	d.Set("lastname", fu.Lastname)
+	d.Set("mail", fu.Mail)
+	d.Set("description", fu.Description)
+	d.Set("admin", fu.Admin)
+	d.Set("auth_source_id", fu.AuthSourceId)
+	d.Set("default_location_id", fu.DefaultLocationId)
+	d.Set("default_organization_id", fu.DefaultOrganizationId)
+	d.Set("location_ids", fu.LocationIds)
+	d.Set("organization_ids", fu.OrganizationIds)
+	d.Set("role_ids", fu.RoleIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanUserCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_user.go#Create")
+
+	client := meta.(*api.Client)
+	u := buildForemanUser(d)
+
+	createdUser, createErr := client.CreateUser(u)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanUser: [%+v]", createdUser)
+
+	setResourceDataFromForemanUser(d, createdUser)
+
+	return nil
+}
+
+func resourceForemanUserRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_user.go#Read")
+
+	client := meta.(*api.Client)
+	u := buildForemanUser(d)
+
+	readUser, readErr := client.ReadUser(u.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanUser: [%+v]", readUser)
+
+	setResourceDataFromForemanUser(d, readUser)
+
+	return nil
+}
+
+func resourceForemanUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_user.go#Update")
+
+	client := meta.(*api.Client)
+	u := buildForemanUser(d)
+
+	// NOTE(ALL): only send the password if it changed
+	if !d.HasChange("password") {
+		u.Password = ""
+	}
+
+	updatedUser, updateErr := client.UpdateUser(u)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanUser: [%+v]", updatedUser)
+
+	setResourceDataFromForemanUser(d, updatedUser)
+
+	return nil
+}
+
+func resourceForemanUserDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_user.go#Delete")
+
+	client := meta.(*api.Client)
+	u := buildForemanUser(d)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteUser(u.Id)
+}
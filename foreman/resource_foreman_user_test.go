@@ -0,0 +1,445 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const UsersURI = api.FOREMAN_API_URL_PREFIX + "/users"
+const UsersTestDataPath = "testdata/1.11/users"
+
+// Given a ForemanUser, create a mock instance state reference
+func ForemanUserToInstanceState(obj api.ForemanUser) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanUser
+	attr := map[string]string{}
+	attr["login"] = obj.Login
+	attr["firstname"] = obj.Firstname
+	attr["lastname"] = obj.Lastname
+	attr["mail"] = obj.Mail
+	attr["description"] = obj.Description
+	attr["admin"] = strconv.FormatBool(obj.Admin)
+	attr["auth_source_id"] = strconv.Itoa(obj.AuthSourceId)
+	attr["default_location_id"] = strconv.Itoa(obj.DefaultLocationId)
+	attr["default_organization_id"] = strconv.Itoa(obj.DefaultOrganizationId)
+	attr["location_ids.#"] = strconv.Itoa(len(obj.LocationIds))
+	for idx, val := range obj.LocationIds {
+		key := fmt.Sprintf("location_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["organization_ids.#"] = strconv.Itoa(len(obj.OrganizationIds))
+	for idx, val := range obj.OrganizationIds {
+		key := fmt.Sprintf("organization_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	attr["role_ids.#"] = strconv.Itoa(len(obj.RoleIds))
+	for idx, val := range obj.RoleIds {
+		key := fmt.Sprintf("role_ids.%d", idx)
+		attr[key] = strconv.Itoa(val)
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanUser resource, create a
+// mock ResourceData reference.
+func MockForemanUserResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanUser()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a user
+// ResourceData reference
+func MockForemanUserResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanUser
+	ParseJSONFile(t, path, &obj)
+	s := ForemanUserToInstanceState(obj)
+	return MockForemanUserResourceData(s)
+}
+
+// Creates a random ForemanUser struct
+func RandForemanUser() api.ForemanUser {
+	obj := api.ForemanUser{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Login = tfrand.String(10, tfrand.Lower)
+	obj.Firstname = tfrand.String(10, tfrand.Lower)
+	obj.Lastname = tfrand.String(10, tfrand.Lower)
+	obj.Mail = tfrand.String(10, tfrand.Lower) + "@company.com"
+	obj.Description = tfrand.String(30, tfrand.Lower)
+	obj.Admin = rand.Intn(2) > 0
+	obj.AuthSourceId = rand.Intn(100) + 1
+	obj.DefaultLocationId = rand.Intn(100)
+	obj.DefaultOrganizationId = rand.Intn(100)
+	obj.LocationIds = tfrand.IntArrayUnique(5)
+	obj.OrganizationIds = tfrand.IntArrayUnique(5)
+	obj.RoleIds = tfrand.IntArrayUnique(5)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanUser resoure.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanUserResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanUser()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, setAttr := range []string{"location_ids", "organization_ids", "role_ids"} {
+		attr1, ok1 := r1.Get(setAttr).(*schema.Set)
+		attr2, ok2 := r2.Get(setAttr).(*schema.Set)
+		if ok1 && ok2 {
+			if !attr1.Equal(attr2) {
+				t.Fatalf(
+					"ResourceData reference differ in %s. "+
+						"[%v], [%v]",
+					setAttr,
+					attr1.List(),
+					attr2.List(),
+				)
+			}
+		} else if (ok1 && !ok2) || (!ok1 && ok2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. "+
+					"[%T], [%T]",
+				setAttr,
+				attr1,
+				attr2,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestUserUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanUser
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanUser UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanUser UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanUser
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanUser
+func TestBuildForemanUser(t *testing.T) {
+
+	expectedObj := RandForemanUser()
+	expectedState := ForemanUserToInstanceState(expectedObj)
+	expectedResourceData := MockForemanUserResourceData(expectedState)
+
+	actualObj := *buildForemanUser(expectedResourceData)
+
+	actualState := ForemanUserToInstanceState(actualObj)
+	actualResourceData := MockForemanUserResourceData(actualState)
+
+	ForemanUserResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanUser
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanUser_Value(t *testing.T) {
+
+	expectedObj := RandForemanUser()
+	expectedState := ForemanUserToInstanceState(expectedObj)
+	expectedResourceData := MockForemanUserResourceData(expectedState)
+
+	actualObj := api.ForemanUser{}
+	actualState := ForemanUserToInstanceState(actualObj)
+	actualResourceData := MockForemanUserResourceData(actualState)
+
+	setResourceDataFromForemanUser(actualResourceData, &expectedObj)
+
+	ForemanUserResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanUserCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanUser{}
+	obj.Id = rand.Intn(100)
+	s := ForemanUserToInstanceState(obj)
+	usersURIById := UsersURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserCreate",
+				crudFunc:     resourceForemanUserCreate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedURI:    UsersURI,
+			expectedMethod: http.MethodPost,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserRead",
+				crudFunc:     resourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedURI:    usersURIById,
+			expectedMethod: http.MethodGet,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserUpdate",
+				crudFunc:     resourceForemanUserUpdate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedURI:    usersURIById,
+			expectedMethod: http.MethodPut,
+		},
+		TestCaseCorrectURLAndMethod{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserDelete",
+				crudFunc:     resourceForemanUserDelete,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedURI:    usersURIById,
+			expectedMethod: http.MethodDelete,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanUserRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanUser{}
+	obj.Id = rand.Intn(100)
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanUserRead",
+			crudFunc:     resourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserDelete",
+			crudFunc:     resourceForemanUserDelete,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanUserRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := api.ForemanUser{}
+	obj.Id = rand.Intn(100)
+	s := ForemanUserToInstanceState(obj)
+
+	rd := MockForemanUserResourceData(s)
+	obj = *buildForemanUser(rd)
+	reqData, _ := api.WrapJson("user", obj)
+
+	return []TestCaseRequestData{
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserCreate",
+				crudFunc:     resourceForemanUserCreate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		TestCaseRequestData{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserUpdate",
+				crudFunc:     resourceForemanUserUpdate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanUserStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanUser{}
+	obj.Id = rand.Intn(100)
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanUserCreate",
+			crudFunc:     resourceForemanUserCreate,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserRead",
+			crudFunc:     resourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserUpdate",
+			crudFunc:     resourceForemanUserUpdate,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserDelete",
+			crudFunc:     resourceForemanUserDelete,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanUserEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanUser{}
+	obj.Id = rand.Intn(100)
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCase{
+		TestCase{
+			funcName:     "resourceForemanUserCreate",
+			crudFunc:     resourceForemanUserCreate,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserRead",
+			crudFunc:     resourceForemanUserRead,
+			resourceData: MockForemanUserResourceData(s),
+		},
+		TestCase{
+			funcName:     "resourceForemanUserUpdate",
+			crudFunc:     resourceForemanUserUpdate,
+			resourceData: MockForemanUserResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanUserMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanUser()
+	s := ForemanUserToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserCreate",
+				crudFunc:     resourceForemanUserCreate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: UsersTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanUserResourceDataFromFile(
+				t,
+				UsersTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanUserResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserRead",
+				crudFunc:     resourceForemanUserRead,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: UsersTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanUserResourceDataFromFile(
+				t,
+				UsersTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanUserResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		TestCaseMockResponse{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserUpdate",
+				crudFunc:     resourceForemanUserUpdate,
+				resourceData: MockForemanUserResourceData(s),
+			},
+			responseFile: UsersTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanUserResourceDataFromFile(
+				t,
+				UsersTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanUserResourceDataCompare,
+		},
+	}
+
+}
@@ -0,0 +1,220 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceForemanUsergroup() *schema.Resource {
+	return &schema.Resource{
+
+		Create: resourceForemanUsergroupCreate,
+		Read:   resourceForemanUsergroupRead,
+		Update: resourceForemanUsergroupUpdate,
+		Delete: resourceForemanUsergroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A group of users. The members of a usergroup inherit the "+
+						"roles assigned to the usergroup.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the usergroup. "+
+						"%s \"engineering\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"admin": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether or not the members of the usergroup are " +
+					"administrators. Defaults to `false`.",
+			},
+
+			// -- Foreign Key Relationships --
+
+			"user_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the users which are members of the usergroup. " +
+					"Leave unset if the members are synchronized from an external " +
+					"usergroup.",
+			},
+
+			"usergroup_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the usergroups which are nested members of the " +
+					"usergroup.",
+			},
+
+			"role_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the roles assigned to the usergroup.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanUsergroup constructs a ForemanUsergroup reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanUsergroup(d *schema.ResourceData) *api.ForemanUsergroup {
+	log.Tracef("resource_foreman_usergroup.go#buildForemanUsergroup")
+
+	usergroup := api.ForemanUsergroup{}
+
+	obj := buildForemanObject(d)
+	usergroup.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	usergroup.Admin = d.Get("admin").(bool)
+
+	if attr, ok = d.GetOk("user_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		usergroup.UserIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("usergroup_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		usergroup.UsergroupIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	if attr, ok = d.GetOk("role_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		usergroup.RoleIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+
+	return &usergroup
+}
+
+// setResourceDataFromForemanUsergroup sets a ResourceData's attributes from
+// the attributes of the supplied ForemanUsergroup reference
+func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fu *api.ForemanUsergroup) {
+	log.Tracef("resource_foreman_usergroup.go#setResourceDataFromForemanUsergroup")
+
+	d.SetId(strconv.Itoa(fu.Id))
+	d.Set("name", fu.Name)
+	d.Set("admin", fu.Admin)
+	d.Set("user_ids", fu.UserIds)
+	d.Set("usergroup_ids", fu.UsergroupIds)
+	d.Set("role_ids", fu.RoleIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanUsergroupCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup.go#Create")
+
+	client := meta.(*api.Client)
+	u := buildForemanUsergroup(d)
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	createdUsergroup, createErr := client.CreateUsergroup(u)
+	if createErr != nil {
+		return createErr
+	}
+
+	log.Debugf("Created ForemanUsergroup: [%+v]", createdUsergroup)
+
+	setResourceDataFromForemanUsergroup(d, createdUsergroup)
+
+	return nil
+}
+
+func resourceForemanUsergroupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup.go#Read")
+
+	client := meta.(*api.Client)
+	u := buildForemanUsergroup(d)
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	readUsergroup, readErr := client.ReadUsergroup(u.Id)
+	if readErr != nil {
+		return handleNotFoundError(readErr, d)
+	}
+
+	log.Debugf("Read ForemanUsergroup: [%+v]", readUsergroup)
+
+	setResourceDataFromForemanUsergroup(d, readUsergroup)
+
+	return nil
+}
+
+func resourceForemanUsergroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup.go#Update")
+
+	client := meta.(*api.Client)
+	u := buildForemanUsergroup(d)
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	updatedUsergroup, updateErr := client.UpdateUsergroup(u)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	log.Debugf("Updated ForemanUsergroup: [%+v]", updatedUsergroup)
+
+	setResourceDataFromForemanUsergroup(d, updatedUsergroup)
+
+	return nil
+}
+
+func resourceForemanUsergroupDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Tracef("resource_foreman_usergroup.go#Delete")
+
+	client := meta.(*api.Client)
+	u := buildForemanUsergroup(d)
+
+	log.Debugf("ForemanUsergroup: [%+v]", u)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return client.DeleteUsergroup(u.Id)
+}
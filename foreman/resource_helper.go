@@ -1,11 +1,21 @@
 package foreman
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	ctyyaml "github.com/zclconf/go-cty-yaml"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 // buildForemanObject constructs a base ForemanObject reference from a
@@ -41,3 +51,389 @@ func buildForemanObject(d *schema.ResourceData) *api.ForemanObject {
 
 	return &obj
 }
+
+// handleNotFoundError removes the resource from the state when the error is a
+// 404 Not Found response from Foreman.  This happens when the object was
+// deleted outside of terraform and lets terraform plan to re-create it instead
+// of failing every plan.  Any other error is returned unchanged.
+func handleNotFoundError(err error, d *schema.ResourceData) error {
+	if api.IsNotFound(err) {
+		log.Warningf(
+			"Object with ID [%s] no longer exists in Foreman, removing it "+
+				"from the state",
+			d.Id(),
+		)
+		d.SetId("")
+		return nil
+	}
+	return err
+}
+
+// buildForemanIds returns the IDs of the set attribute with the given key.  The
+// list is nil if the attribute is neither configured nor changed, so it is not
+// sent to Foreman and the existing associations are kept.  It is empty if the
+// attribute was changed to empty, so the associations are removed.
+func buildForemanIds(d *schema.ResourceData, key string) []int {
+	if attr, ok := d.GetOk(key); ok {
+		return conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	if d.HasChange(key) {
+		return []int{}
+	}
+	return nil
+}
+
+// foremanParameterTypes are the types Foreman can parse a parameter value as
+var foremanParameterTypes = []string{
+	"string",
+	"boolean",
+	"integer",
+	"real",
+	"array",
+	"hash",
+	"yaml",
+	"json",
+}
+
+// foremanParameterSchema returns the schema of the "parameter" block of an
+// object with inline parameters.  Unlike the "parameters" map, the block sets
+// the type of each parameter and whether its value is hidden.
+//
+// NOTE(ALL): The block is a list keyed by the parameter name rather than a
+//   set.  The SDK only diffs the elements of a set by their hash, so a set
+//   hashed by name would never plan a changed value, while a set hashed by
+//   value would plan every change as a removal and an addition.  The list
+//   diffs each value in place, which lets the value's DiffSuppressFunc
+//   suppress a "yaml" or "json" value Foreman returns in a different format.
+//
+// NOTE(ALL): The SDK can only redact an attribute as a whole, not the values
+//   of the hidden parameters alone.  Every value of the block is Sensitive so
+//   the values of hidden parameters do not show in plan output.  Like any
+//   Sensitive attribute, the values are still stored in the state.
+func foremanParameterSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"parameters"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": &schema.Schema{
+					Type:             schema.TypeString,
+					Required:         true,
+					Sensitive:        true,
+					DiffSuppressFunc: suppressEquivalentForemanParameterValue,
+					Description: "Value of the parameter.  It must be valid for " +
+						"the parameter_type, ie: \"true\" for a \"boolean\".  The " +
+						"value is redacted from plan output since it may be hidden.",
+				},
+				"parameter_type": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "string",
+					ValidateFunc: validation.StringInSlice(foremanParameterTypes, false),
+					Description: fmt.Sprintf(
+						"Type Foreman parses the value as. Valid values: %s.",
+						strings.Join(foremanParameterTypes, ", "),
+					),
+				},
+				"hidden_value": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether Foreman hides the value of the parameter.",
+				},
+			},
+		},
+		Description: fmt.Sprintf(
+			"Typed parameters of the %s. Conflicts with \"parameters\".",
+			objectName,
+		),
+	}
+}
+
+// normalizeForemanParameterValue returns the canonical form of a parameter
+// value for the parameter type.  Values of a structured type are converted to
+// compact JSON with sorted keys.  An error is returned if Foreman cannot parse
+// the value as the type.
+func normalizeForemanParameterValue(paramType string, value string) (string, error) {
+	switch paramType {
+	case "", "string":
+		return value, nil
+	case "boolean":
+		// Foreman casts the same strings to a boolean as below
+		switch strings.ToLower(value) {
+		case "true", "t", "yes", "y", "on", "1":
+			return "true", nil
+		case "false", "f", "no", "n", "off", "0":
+			return "false", nil
+		}
+		return "", fmt.Errorf("[%s] is not a valid boolean", value)
+	case "integer":
+		intVal, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil {
+			return "", fmt.Errorf("[%s] is not a valid integer", value)
+		}
+		return strconv.FormatInt(intVal, 10), nil
+	case "real":
+		floatVal, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			return "", fmt.Errorf("[%s] is not a valid real", value)
+		}
+		return strconv.FormatFloat(floatVal, 'f', -1, 64), nil
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return "", fmt.Errorf("[%s] is not valid JSON", value)
+		}
+	case "array", "hash", "yaml":
+	default:
+		return "", fmt.Errorf("[%s] is not a valid parameter type", paramType)
+	}
+
+	// JSON is a subset of YAML, so structured values of all types are parsed
+	// as YAML
+	ty, typeErr := ctyyaml.Standard.ImpliedType([]byte(value))
+	if typeErr != nil {
+		return "", fmt.Errorf("[%s] is not valid %s: %s", value, paramType, typeErr)
+	}
+	if paramType == "array" && !ty.IsTupleType() && !ty.IsListType() {
+		return "", fmt.Errorf("[%s] is not a valid array", value)
+	}
+	if paramType == "hash" && !ty.IsObjectType() && !ty.IsMapType() {
+		return "", fmt.Errorf("[%s] is not a valid hash", value)
+	}
+
+	var val cty.Value
+	var convErr error
+	if val, convErr = ctyyaml.Standard.Unmarshal([]byte(value), ty); convErr != nil {
+		return "", fmt.Errorf("[%s] is not valid %s: %s", value, paramType, convErr)
+	}
+	valueBytes, jsonEncErr := ctyjson.Marshal(val, ty)
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+	return string(valueBytes), nil
+}
+
+// suppressEquivalentForemanParameterValue is a DiffSuppressFunc for the
+// "value" of a parameter with a "parameter_type", either at the top level of
+// a resource or within an element of the "parameter" block.  Values which
+// normalize to the same value are equivalent.
+func suppressEquivalentForemanParameterValue(k, old, new string, d *schema.ResourceData) bool {
+	// the type is the sibling of the value, ie: "parameter.1234.parameter_type"
+	typeKey := strings.TrimSuffix(k, "value") + "parameter_type"
+	paramType, _ := d.Get(typeKey).(string)
+	oldNormalized, oldErr := normalizeForemanParameterValue(paramType, old)
+	if oldErr != nil {
+		return false
+	}
+	newNormalized, newErr := normalizeForemanParameterValue(paramType, new)
+	if newErr != nil {
+		return false
+	}
+	return oldNormalized == newNormalized
+}
+
+// customizeDiffForemanParameter is a CustomizeDiff function for a resource
+// with a "value" and a "parameter_type".  The diff is rejected at plan time
+// if Foreman cannot parse the value as the type.
+func customizeDiffForemanParameter(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("value") || !d.NewValueKnown("parameter_type") {
+		return nil
+	}
+	paramType, _ := d.Get("parameter_type").(string)
+	value, _ := d.Get("value").(string)
+	if _, normalizeErr := normalizeForemanParameterValue(paramType, value); normalizeErr != nil {
+		return fmt.Errorf("Invalid value for parameter_type [%s]: %s", paramType, normalizeErr)
+	}
+	return nil
+}
+
+// customizeDiffForemanParameters is a CustomizeDiff function for an object
+// with a "parameter" block.  The diff is rejected at plan time if a parameter
+// is set more than once or if Foreman cannot parse the value of a parameter
+// as its type.
+func customizeDiffForemanParameters(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("parameter") {
+		return nil
+	}
+	paramList, ok := d.Get("parameter").([]interface{})
+	if !ok {
+		return nil
+	}
+	names := make(map[string]bool, len(paramList))
+	for idx, elem := range paramList {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("parameter.%d.", idx)
+		name, _ := m["name"].(string)
+		if d.NewValueKnown(key + "name") {
+			if names[name] {
+				return fmt.Errorf("Duplicate parameter [%s]", name)
+			}
+			names[name] = true
+		}
+		value, _ := m["value"].(string)
+		paramType, _ := m["parameter_type"].(string)
+		if !d.NewValueKnown(key+"value") || !d.NewValueKnown(key+"parameter_type") {
+			continue
+		}
+		if _, normalizeErr := normalizeForemanParameterValue(paramType, value); normalizeErr != nil {
+			return fmt.Errorf("Invalid value for parameter [%s]: %s", name, normalizeErr)
+		}
+	}
+	return nil
+}
+
+// buildForemanKVParameters constructs the inline parameters of an object from
+// the "parameter" block of a ResourceData reference, or from its "parameters"
+// map if the block is not set.  The parameters are sorted by name.
+func buildForemanKVParameters(d *schema.ResourceData) []api.ForemanKVParameter {
+	if attr, ok := d.GetOk("parameter"); ok {
+		paramList := attr.([]interface{})
+		params := make([]api.ForemanKVParameter, len(paramList))
+		for idx, elem := range paramList {
+			m := elem.(map[string]interface{})
+			params[idx] = api.ForemanKVParameter{
+				Name:          m["name"].(string),
+				Value:         m["value"].(string),
+				ParameterType: m["parameter_type"].(string),
+				HiddenValue:   m["hidden_value"].(bool),
+			}
+		}
+		sort.Slice(params, func(i, j int) bool {
+			return params[i].Name < params[j].Name
+		})
+		return params
+	}
+
+	attr, ok := d.GetOk("parameters")
+	if !ok {
+		return nil
+	}
+	paramMap := attr.(map[string]interface{})
+
+	names := make([]string, 0, len(paramMap))
+	for name := range paramMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]api.ForemanKVParameter, len(names))
+	for idx, name := range names {
+		params[idx] = api.ForemanKVParameter{
+			Name:  name,
+			Value: paramMap[name].(string),
+		}
+	}
+	return params
+}
+
+// foremanKVParametersToMap converts the inline parameters of an object to the
+// value of a "parameters" map attribute.
+func foremanKVParametersToMap(params []api.ForemanKVParameter) map[string]interface{} {
+	paramMap := make(map[string]interface{}, len(params))
+	for _, param := range params {
+		paramMap[param.Name] = param.Value
+	}
+	return paramMap
+}
+
+// setForemanKVParameters sets the inline parameters of an object on the
+// "parameter" block of a ResourceData reference if the block is in use,
+// otherwise on its "parameters" map.  Parameters known to the block keep
+// their position in it, any other parameters are appended sorted by name.
+//
+// NOTE(ALL): Foreman masks the values of hidden parameters when reading
+//   them.  The value of a hidden parameter known to the ResourceData is kept.
+func setForemanKVParameters(d *schema.ResourceData, params []api.ForemanKVParameter) {
+	attr, ok := d.GetOk("parameter")
+	if !ok {
+		d.Set("parameters", foremanKVParametersToMap(params))
+		return
+	}
+
+	positions := map[string]int{}
+	hiddenValues := map[string]string{}
+	for idx, elem := range attr.([]interface{}) {
+		m := elem.(map[string]interface{})
+		positions[m["name"].(string)] = idx
+		if hidden, _ := m["hidden_value"].(bool); hidden {
+			hiddenValues[m["name"].(string)] = m["value"].(string)
+		}
+	}
+
+	sorted := make([]api.ForemanKVParameter, len(params))
+	copy(sorted, params)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iPos, iKnown := positions[sorted[i].Name]
+		jPos, jKnown := positions[sorted[j].Name]
+		if iKnown && jKnown {
+			return iPos < jPos
+		}
+		if iKnown != jKnown {
+			return iKnown
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	paramList := make([]interface{}, len(sorted))
+	for idx, param := range sorted {
+		value := param.Value
+		if hiddenValue, ok := hiddenValues[param.Name]; ok && param.HiddenValue {
+			value = hiddenValue
+		}
+		paramType := param.ParameterType
+		if paramType == "" {
+			paramType = "string"
+		}
+		paramList[idx] = map[string]interface{}{
+			"name":           param.Name,
+			"value":          value,
+			"parameter_type": paramType,
+			"hidden_value":   param.HiddenValue,
+		}
+	}
+	d.Set("parameter", paramList)
+}
+
+// reconcileForemanKVParameters builds the inline parameters to send with an
+// update of an object.  The desired parameters receive the ID of the current
+// parameter with the same name, so Foreman updates rather than duplicates
+// them.  Desired parameters without a type, ie: from a "parameters" map, also
+// keep the type and hidden flag of the current parameter.  Current parameters
+// which are no longer desired are tagged with the "_destroy" flag to remove
+// them from the object.
+func reconcileForemanKVParameters(current, desired []api.ForemanKVParameter) []api.ForemanKVParameter {
+	currentIds := make(map[string]int, len(current))
+	currentParams := make(map[string]api.ForemanKVParameter, len(current))
+	for _, param := range current {
+		currentIds[param.Name] = param.Id
+		currentParams[param.Name] = param
+	}
+
+	params := []api.ForemanKVParameter{}
+	for _, param := range desired {
+		param.Id = currentIds[param.Name]
+		if currentParam, ok := currentParams[param.Name]; ok && param.ParameterType == "" {
+			param.ParameterType = currentParam.ParameterType
+			param.HiddenValue = currentParam.HiddenValue
+		}
+		delete(currentIds, param.Name)
+		params = append(params, param)
+	}
+	for _, param := range current {
+		if _, ok := currentIds[param.Name]; !ok {
+			continue
+		}
+		param.Destroy = true
+		params = append(params, param)
+	}
+
+	return params
+}
@@ -0,0 +1,311 @@
+package foreman
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/HanseMerkur/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// unknownVariableValue is the placeholder the SDK reads a configured value as
+// which is not known during plan
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// -----------------------------------------------------------------------------
+// reconcileForemanKVParameters
+// -----------------------------------------------------------------------------
+
+// Ensures desired parameters keep the ID of the current parameter with the
+// same name, untyped parameters keep the type and hidden flag of the current
+// parameter and current parameters which are no longer desired are destroyed
+func TestReconcileForemanKVParameters(t *testing.T) {
+	current := []api.ForemanKVParameter{
+		{Id: 1, Name: "install_server", Value: "install.dev.dc1.company.com"},
+		{Id: 2, Name: "ntp_server", Value: "ntp.dev.dc1.company.com"},
+		{Id: 3, Name: "root_pw", Value: "secret", ParameterType: "string", HiddenValue: true},
+		{Id: 4, Name: "enable_epel", Value: "true", ParameterType: "boolean"},
+	}
+	desired := []api.ForemanKVParameter{
+		{Name: "enable_epel", Value: "false", ParameterType: "boolean"},
+		{Name: "install_server", Value: "install.prod.dc1.company.com"},
+		{Name: "proxy", Value: "proxy.dev.dc1.company.com"},
+		{Name: "root_pw", Value: "changed"},
+	}
+
+	expected := []api.ForemanKVParameter{
+		{Id: 4, Name: "enable_epel", Value: "false", ParameterType: "boolean"},
+		{Id: 1, Name: "install_server", Value: "install.prod.dc1.company.com"},
+		{Name: "proxy", Value: "proxy.dev.dc1.company.com"},
+		{Id: 3, Name: "root_pw", Value: "changed", ParameterType: "string", HiddenValue: true},
+		{Id: 2, Name: "ntp_server", Value: "ntp.dev.dc1.company.com", Destroy: true},
+	}
+
+	params := reconcileForemanKVParameters(current, desired)
+	if !reflect.DeepEqual(params, expected) {
+		t.Fatalf(
+			"reconcileForemanKVParameters() did not reconcile the parameters. "+
+				"Expected [%+v], got [%+v]",
+			expected,
+			params,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// normalizeForemanParameterValue
+// -----------------------------------------------------------------------------
+
+// Ensures values are normalized for their parameter type and values which
+// Foreman cannot parse as the type are rejected
+func TestNormalizeForemanParameterValue(t *testing.T) {
+	testCases := []struct {
+		paramType string
+		value     string
+		expected  string
+		returnErr bool
+	}{
+		{"string", " foo ", " foo ", false},
+		{"", "foo", "foo", false},
+		{"boolean", "Yes", "true", false},
+		{"boolean", "0", "false", false},
+		{"boolean", "maybe", "", true},
+		{"integer", "042", "42", false},
+		{"integer", "4.2", "", true},
+		{"real", "2.50", "2.5", false},
+		{"real", "two", "", true},
+		{"array", "[1, 2]", "[1,2]", false},
+		{"array", "- a\n- b\n", `["a","b"]`, false},
+		{"array", "a: 1", "", true},
+		{"hash", `{"b": 2, "a": 1}`, `{"a":1,"b":2}`, false},
+		{"hash", "b: 2\na: 1\n", `{"a":1,"b":2}`, false},
+		{"hash", "[1, 2]", "", true},
+		{"yaml", "a:\n  - 1\n", `{"a":[1]}`, false},
+		{"yaml", "a: [1", "", true},
+		{"json", `{ "a" : [ 1 ] }`, `{"a":[1]}`, false},
+		{"json", "a: 1", "", true},
+		{"xml", "<a/>", "", true},
+	}
+
+	for _, testCase := range testCases {
+		normalized, normalizeErr := normalizeForemanParameterValue(testCase.paramType, testCase.value)
+		if testCase.returnErr {
+			if normalizeErr == nil {
+				t.Errorf(
+					"normalizeForemanParameterValue(%q, %q) did not return an error",
+					testCase.paramType,
+					testCase.value,
+				)
+			}
+			continue
+		}
+		if normalizeErr != nil {
+			t.Errorf(
+				"normalizeForemanParameterValue(%q, %q) returned an error: [%s]",
+				testCase.paramType,
+				testCase.value,
+				normalizeErr,
+			)
+			continue
+		}
+		if normalized != testCase.expected {
+			t.Errorf(
+				"normalizeForemanParameterValue(%q, %q) did not normalize the "+
+					"value. Expected [%s], got [%s]",
+				testCase.paramType,
+				testCase.value,
+				testCase.expected,
+				normalized,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// suppressEquivalentForemanParameterValue
+// -----------------------------------------------------------------------------
+
+// Ensures a value of the "parameter" block which Foreman returns in a different
+// format does not cause a diff while a changed value does
+func TestSuppressEquivalentForemanParameterValue(t *testing.T) {
+	r := resourceForemanDomain()
+
+	stateData := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "dev.dc1.company.com",
+		"parameter": []interface{}{
+			map[string]interface{}{
+				"name":           "ntp",
+				"value":          `{"servers":["0.pool.ntp.org","1.pool.ntp.org"]}`,
+				"parameter_type": "yaml",
+			},
+		},
+	})
+	stateData.SetId("1")
+	state := stateData.State()
+
+	testCases := []struct {
+		value      string
+		expectDiff bool
+	}{
+		{"servers:\n  - 0.pool.ntp.org\n  - 1.pool.ntp.org\n", false},
+		{"servers:\n  - 2.pool.ntp.org\n", true},
+	}
+
+	for _, testCase := range testCases {
+		cfg := map[string]interface{}{
+			"name": "dev.dc1.company.com",
+			"parameter": []interface{}{
+				map[string]interface{}{
+					"name":           "ntp",
+					"value":          testCase.value,
+					"parameter_type": "yaml",
+				},
+			},
+		}
+		diff, diffErr := r.Diff(state, terraform.NewResourceConfigRaw(cfg), nil)
+		if diffErr != nil {
+			t.Fatalf("Diff() returned an error: [%s]", diffErr)
+		}
+		if hasDiff := diff != nil && !diff.Empty(); hasDiff != testCase.expectDiff {
+			t.Errorf(
+				"Diff() of the value [%s] expected a diff [%t], got [%+v]",
+				testCase.value,
+				testCase.expectDiff,
+				diff,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// customizeDiffForemanParameters
+// -----------------------------------------------------------------------------
+
+// Ensures a typed parameter whose value is not valid for its type is rejected
+// at plan time while unknown values are skipped
+func TestCustomizeDiffForemanParameters(t *testing.T) {
+	r := resourceForemanDomain()
+
+	testCases := []struct {
+		value     string
+		returnErr bool
+	}{
+		{"true", false},
+		{"maybe", true},
+		{unknownVariableValue, false},
+	}
+
+	for _, testCase := range testCases {
+		cfg := map[string]interface{}{
+			"name": "dev.dc1.company.com",
+			"parameter": []interface{}{
+				map[string]interface{}{
+					"name":           "enable_epel",
+					"value":          testCase.value,
+					"parameter_type": "boolean",
+				},
+			},
+		}
+		_, diffErr := r.Diff(nil, terraform.NewResourceConfigRaw(cfg), nil)
+		if testCase.returnErr {
+			if diffErr == nil || !strings.Contains(diffErr.Error(), "enable_epel") {
+				t.Errorf(
+					"Diff() did not reject the value [%s]. Got error [%v]",
+					testCase.value,
+					diffErr,
+				)
+			}
+		} else if diffErr != nil {
+			t.Errorf(
+				"Diff() rejected the value [%s]: [%s]",
+				testCase.value,
+				diffErr,
+			)
+		}
+	}
+}
+
+// Ensures a parameter set more than once in the block is rejected at plan time
+func TestCustomizeDiffForemanParameters_Duplicate(t *testing.T) {
+	r := resourceForemanDomain()
+
+	cfg := map[string]interface{}{
+		"name": "dev.dc1.company.com",
+		"parameter": []interface{}{
+			map[string]interface{}{
+				"name":  "ntp_server",
+				"value": "0.pool.ntp.org",
+			},
+			map[string]interface{}{
+				"name":  "ntp_server",
+				"value": "1.pool.ntp.org",
+			},
+		},
+	}
+	_, diffErr := r.Diff(nil, terraform.NewResourceConfigRaw(cfg), nil)
+	if diffErr == nil || !strings.Contains(diffErr.Error(), "Duplicate parameter") {
+		t.Errorf("Diff() did not reject the duplicate parameter. Got error [%v]", diffErr)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// setForemanKVParameters
+// -----------------------------------------------------------------------------
+
+// Ensures parameters read from Foreman keep their position in the block, new
+// parameters are appended sorted by name and known hidden values are kept
+func TestSetForemanKVParameters(t *testing.T) {
+	r := resourceForemanDomain()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "dev.dc1.company.com",
+		"parameter": []interface{}{
+			map[string]interface{}{
+				"name":  "ntp_server",
+				"value": "ntp.dev.dc1.company.com",
+			},
+			map[string]interface{}{
+				"name":         "root_pw",
+				"value":        "secret",
+				"hidden_value": true,
+			},
+		},
+	})
+
+	setForemanKVParameters(d, []api.ForemanKVParameter{
+		{Id: 3, Name: "proxy", Value: "proxy.dev.dc1.company.com", ParameterType: "string"},
+		{Id: 2, Name: "root_pw", Value: "*****", ParameterType: "string", HiddenValue: true},
+		{Id: 4, Name: "install_server", Value: "install.dev.dc1.company.com", ParameterType: "string"},
+		{Id: 1, Name: "ntp_server", Value: "ntp.dev.dc1.company.com", ParameterType: "string"},
+	})
+
+	expected := []struct {
+		name  string
+		value string
+	}{
+		{"ntp_server", "ntp.dev.dc1.company.com"},
+		{"root_pw", "secret"},
+		{"install_server", "install.dev.dc1.company.com"},
+		{"proxy", "proxy.dev.dc1.company.com"},
+	}
+	if count := d.Get("parameter.#").(int); count != len(expected) {
+		t.Fatalf("setForemanKVParameters() set [%d] parameters, expected [%d]", count, len(expected))
+	}
+	for idx, param := range expected {
+		name := d.Get(fmt.Sprintf("parameter.%d.name", idx)).(string)
+		value := d.Get(fmt.Sprintf("parameter.%d.value", idx)).(string)
+		if name != param.name || value != param.value {
+			t.Errorf(
+				"setForemanKVParameters() set parameter %d to [%s=%s], expected [%s=%s]",
+				idx,
+				name,
+				value,
+				param.name,
+				param.value,
+			)
+		}
+	}
+}
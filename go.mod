@@ -4,6 +4,8 @@ require (
 	github.com/HanseMerkur/terraform-provider-utils v1.2.1
 	github.com/hashicorp/go-cleanhttp v0.5.1
 	github.com/hashicorp/terraform-plugin-sdk v1.5.0
+	github.com/zclconf/go-cty v1.1.0
+	github.com/zclconf/go-cty-yaml v1.0.1
 )
 
 go 1.13